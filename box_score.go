@@ -0,0 +1,486 @@
+package gsis
+
+import (
+	"sort"
+	"strings"
+)
+
+type PassingStats struct {
+	Attempts            int
+	Completions         int
+	Yards               int
+	Touchdowns          int
+	Interceptions       int
+	Sacks               int
+	SackYards           int
+	Long                int
+	TwoPointConversions int
+}
+
+type RushingStats struct {
+	Attempts            int
+	Yards               int
+	Touchdowns          int
+	Long                int
+	TwoPointConversions int
+}
+
+type ReceivingStats struct {
+	Targets             int
+	Receptions          int
+	Yards               int
+	Touchdowns          int
+	YardsAfterCatch     int
+	Long                int
+	TwoPointConversions int
+}
+
+type DefenseStats struct {
+	// Solo tackles, including tackles made with the help of an assist.
+	Tackles int
+
+	// Assists on a teammate's tackle.
+	Assists int
+
+	// Tackles shared equally with a teammate. Each counts as half of a tackle.
+	HalfTackles int
+
+	Sacks                    float64
+	SackYards                float64
+	TacklesForLoss           float64
+	TacklesForLossYards      int
+	QuarterbackHits          int
+	PassesDefensed           int
+	Interceptions            int
+	InterceptionYards        int
+	InterceptionTouchdowns   int
+	ForcedFumbles            int
+	FumbleRecoveries         int
+	FumbleRecoveryYards      int
+	FumbleRecoveryTouchdowns int
+	Safeties                 float64
+}
+
+// Combined returns the total of tackles and assists, counting each half tackle as half of one.
+func (s DefenseStats) Combined() float64 {
+	return float64(s.Tackles+s.Assists) + float64(s.HalfTackles)/2
+}
+
+// Tackles and takeaways made outside of the defense's usual role.
+type TackleStats struct {
+	Tackles          int
+	Assists          int
+	HalfTackles      int
+	ForcedFumbles    int
+	FumbleRecoveries int
+
+	// Blocked punts, field goals, and extra points.
+	Blocks int
+}
+
+// Combined returns the total of tackles and assists, counting each half tackle as half of one.
+func (s TackleStats) Combined() float64 {
+	return float64(s.Tackles+s.Assists) + float64(s.HalfTackles)/2
+}
+
+type KickingStats struct {
+	FieldGoalAttempts   int
+	FieldGoalsMade      int
+	FieldGoalsBlocked   int
+	FieldGoalYards      int
+	LongFieldGoal       int
+	ExtraPointAttempts  int
+	ExtraPointsMade     int
+	ExtraPointsBlocked  int
+	Kickoffs            int
+	KickoffYards        int
+	KickoffTouchbacks   int
+	KickoffsOutOfBounds int
+}
+
+type PuntingStats struct {
+	Punts      int
+	Yards      int
+	Long       int
+	Inside20   int
+	Touchbacks int
+	Blocked    int
+}
+
+type ReturnStats struct {
+	Returns     int
+	Yards       int
+	Touchdowns  int
+	Long        int
+	FairCatches int
+}
+
+type FumbleStats struct {
+	Fumbles          int
+	Lost             int
+	OutOfBounds      int
+	OwnRecoveries    int
+	OwnRecoveryYards int
+}
+
+// A player's stat line for a game.
+type PlayerBoxScore struct {
+	PlayerID      string
+	PlayerName    string
+	ClubCode      string
+	UniformNumber string
+
+	Passing     PassingStats
+	Rushing     RushingStats
+	Receiving   ReceivingStats
+	Defense     DefenseStats
+	Kicking     KickingStats
+	Punting     PuntingStats
+	KickReturns ReturnStats
+	PuntReturns ReturnStats
+	Fumbles     FumbleStats
+
+	// Tackles, fumbles, and blocks on kicking plays.
+	SpecialTeams TackleStats
+
+	// Tackles and fumbles by the offense, such as after an interception.
+	Miscellaneous TackleStats
+}
+
+type BoxScore struct {
+	// Ordered by club code, then uniform number.
+	Players []*PlayerBoxScore
+}
+
+// Player returns the stat line for a player or nil if the player has no stats.
+func (b *BoxScore) Player(playerID string) *PlayerBoxScore {
+	for _, p := range b.Players {
+		if p.PlayerID == playerID {
+			return p
+		}
+	}
+	return nil
+}
+
+// Team returns the stat lines for a club's players.
+func (b *BoxScore) Team(clubCode string) []*PlayerBoxScore {
+	var ret []*PlayerBoxScore
+	for _, p := range b.Players {
+		if p.ClubCode == clubCode {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// longest returns the new longest gain after the nth gain. Unlike a max, the result can be
+// negative, e.g. when a player's only reception is for a loss.
+func longest(long, n, yards int) int {
+	if n == 1 || yards > long {
+		return yards
+	}
+	return long
+}
+
+type boxScorePlay struct {
+	possessionTeam string
+	kicking        bool
+}
+
+// BoxScore aggregates the play stats into per-player stat lines. Stats for deleted plays and
+// nullified stats are excluded, as are team stats that aren't credited to a player.
+func (f *StatFile) BoxScore() *BoxScore {
+	deleted := map[StringInt]bool{}
+	plays := map[StringInt]*boxScorePlay{}
+	for _, p := range f.Play {
+		if p.PlayDeleted != 0 {
+			deleted[p.PlayID] = true
+		}
+		plays[p.PlayID] = &boxScorePlay{
+			possessionTeam: strings.Trim(p.PossessionTeam, `"`),
+		}
+	}
+	for _, stat := range f.PlayStat {
+		if stat.StatID.Side() == StatSideSpecialTeams {
+			if play, ok := plays[stat.PlayID]; ok {
+				play.kicking = true
+			}
+		}
+	}
+
+	players := map[string]*PlayerBoxScore{}
+	for _, stat := range f.PlayStat {
+		if stat.PlayerID == "" || deleted[stat.PlayID] {
+			continue
+		}
+		p, ok := players[stat.PlayerID]
+		if !ok {
+			p = &PlayerBoxScore{
+				PlayerID:      stat.PlayerID,
+				PlayerName:    stat.PlayerName,
+				ClubCode:      stat.ClubCode,
+				UniformNumber: stat.UniformNumber,
+			}
+			players[stat.PlayerID] = p
+		}
+		play := plays[stat.PlayID]
+		if play == nil {
+			play = &boxScorePlay{}
+		}
+		p.add(stat, play)
+	}
+
+	ret := &BoxScore{
+		Players: make([]*PlayerBoxScore, 0, len(players)),
+	}
+	for _, p := range players {
+		ret.Players = append(ret.Players, p)
+	}
+	sort.Slice(ret.Players, func(i, j int) bool {
+		a, b := ret.Players[i], ret.Players[j]
+		if a.ClubCode != b.ClubCode {
+			return a.ClubCode < b.ClubCode
+		} else if a.UniformNumber != b.UniformNumber {
+			return a.UniformNumber < b.UniformNumber
+		}
+		return a.PlayerID < b.PlayerID
+	})
+	return ret
+}
+
+func (p *PlayerBoxScore) add(stat StatFilePlayStat, play *boxScorePlay) {
+	yards := stat.Yards.Int()
+
+	// Tackles and takeaways that aren't credited to the defense.
+	var tackles *TackleStats
+	if play.kicking {
+		tackles = &p.SpecialTeams
+	} else if play.possessionTeam != "" && stat.ClubCode == play.possessionTeam {
+		tackles = &p.Miscellaneous
+	}
+
+	switch stat.StatID {
+	// Passing. The "no pass" variants credit yards to the passer of a completion that was
+	// lateraled after the catch, so they don't count as attempts.
+	case StatIDPassIncomplete, StatIDInterceptionPasser:
+		p.Passing.Attempts++
+		if stat.StatID == StatIDInterceptionPasser {
+			p.Passing.Interceptions++
+		}
+	case StatIDPassingYards, StatIDPassingYardsTD:
+		p.Passing.Attempts++
+		p.Passing.Completions++
+		p.Passing.Yards += yards
+		p.Passing.Long = longest(p.Passing.Long, p.Passing.Completions, yards)
+		if stat.StatID == StatIDPassingYardsTD {
+			p.Passing.Touchdowns++
+		}
+	case StatIDPassingYardsNoPass, StatIDPassingYardsTDNoPass:
+		p.Passing.Yards += yards
+		if stat.StatID == StatIDPassingYardsTDNoPass {
+			p.Passing.Touchdowns++
+		}
+	case StatIDSackYards:
+		p.Passing.Sacks++
+		p.Passing.SackYards -= yards
+	case StatID2PointPassGood:
+		p.Passing.TwoPointConversions++
+
+	// Rushing
+	case StatIDRushingYards, StatIDRushingYardsTD:
+		p.Rushing.Attempts++
+		p.Rushing.Yards += yards
+		p.Rushing.Long = longest(p.Rushing.Long, p.Rushing.Attempts, yards)
+		if stat.StatID == StatIDRushingYardsTD {
+			p.Rushing.Touchdowns++
+		}
+	case StatIDRushingYardsMinus:
+		// Yards lost without a rushing attempt, which are negative.
+		p.Rushing.Yards += yards
+	case StatIDRushingYardsNoRush, StatIDRushingYardsTDNoRush:
+		p.Rushing.Yards += yards
+		if stat.StatID == StatIDRushingYardsTDNoRush {
+			p.Rushing.Touchdowns++
+		}
+	case StatID2PointRushGood:
+		p.Rushing.TwoPointConversions++
+
+	// Receiving
+	case StatIDPassTarget:
+		p.Receiving.Targets++
+	case StatIDPassReceptionYards, StatIDPassReceptionYardsTD:
+		p.Receiving.Receptions++
+		p.Receiving.Yards += yards
+		p.Receiving.Long = longest(p.Receiving.Long, p.Receiving.Receptions, yards)
+		if stat.StatID == StatIDPassReceptionYardsTD {
+			p.Receiving.Touchdowns++
+		}
+	case StatIDPassReceptionYardsNoReception, StatIDPassReceptionYardsTDNoReception:
+		p.Receiving.Yards += yards
+		if stat.StatID == StatIDPassReceptionYardsTDNoReception {
+			p.Receiving.Touchdowns++
+		}
+	case StatIDYardageGainedAfterCatch:
+		p.Receiving.YardsAfterCatch += yards
+	case StatID2PointPassReceptionGood:
+		p.Receiving.TwoPointConversions++
+
+	// Defense
+	case StatIDSoloTackle, StatIDAssistedTackle:
+		if tackles != nil {
+			tackles.Tackles++
+		} else {
+			p.Defense.Tackles++
+		}
+	case StatIDTackleAssist:
+		if tackles != nil {
+			tackles.Assists++
+		} else {
+			p.Defense.Assists++
+		}
+	case StatIDHalfTackle:
+		if tackles != nil {
+			tackles.HalfTackles++
+		} else {
+			p.Defense.HalfTackles++
+		}
+	case StatIDSackYardsDefense:
+		p.Defense.Sacks++
+		p.Defense.SackYards -= float64(yards)
+	case StatIDHalfSackYardsDefense:
+		p.Defense.Sacks += 0.5
+		p.Defense.SackYards -= float64(yards)
+	case StatIDTackleForLoss:
+		p.Defense.TacklesForLoss++
+	case StatIDHalfTackleForLoss:
+		p.Defense.TacklesForLoss += 0.5
+	case StatIDTackleForLossYardage:
+		p.Defense.TacklesForLossYards += yards
+	case StatIDQuarterbackHit:
+		p.Defense.QuarterbackHits++
+	case StatIDPassDefensed:
+		p.Defense.PassesDefensed++
+	case StatIDInterceptionYards, StatIDInterceptionYardsTD:
+		p.Defense.Interceptions++
+		p.Defense.InterceptionYards += yards
+		if stat.StatID == StatIDInterceptionYardsTD {
+			p.Defense.InterceptionTouchdowns++
+		}
+	case StatIDInterceptionYardsNoInterception, StatIDInterceptionYardsTDNoInterception:
+		p.Defense.InterceptionYards += yards
+		if stat.StatID == StatIDInterceptionYardsTDNoInterception {
+			p.Defense.InterceptionTouchdowns++
+		}
+	case StatIDForcedFumble:
+		if tackles != nil {
+			tackles.ForcedFumbles++
+		} else {
+			p.Defense.ForcedFumbles++
+		}
+	case StatIDOpponentRecoveryYards, StatIDOpponentRecoveryYardsTD:
+		if tackles != nil {
+			tackles.FumbleRecoveries++
+		} else {
+			p.Defense.FumbleRecoveries++
+		}
+		p.Defense.FumbleRecoveryYards += yards
+		if stat.StatID == StatIDOpponentRecoveryYardsTD {
+			p.Defense.FumbleRecoveryTouchdowns++
+		}
+	case StatIDOpponentRecoveryYardsNoRecovery, StatIDOpponentRecoveryYardsTDNoRecovery:
+		p.Defense.FumbleRecoveryYards += yards
+		if stat.StatID == StatIDOpponentRecoveryYardsTDNoRecovery {
+			p.Defense.FumbleRecoveryTouchdowns++
+		}
+	case StatIDSafetyDefense:
+		p.Defense.Safeties++
+	case StatIDHalfSafetyDefense:
+		p.Defense.Safeties += 0.5
+	case StatIDPuntBlockedDefense, StatIDExtraPointBlockedDefense, StatIDFieldGoalBlockedDefense:
+		p.SpecialTeams.Blocks++
+
+	// Kicking
+	case StatIDFieldGoalYards:
+		p.Kicking.FieldGoalAttempts++
+		p.Kicking.FieldGoalsMade++
+		p.Kicking.FieldGoalYards += yards
+		p.Kicking.LongFieldGoal = longest(p.Kicking.LongFieldGoal, p.Kicking.FieldGoalsMade, yards)
+	case StatIDFieldGoalMissedYards:
+		p.Kicking.FieldGoalAttempts++
+	case StatIDFieldGoalBlockedOffense:
+		p.Kicking.FieldGoalAttempts++
+		p.Kicking.FieldGoalsBlocked++
+	case StatIDExtraPointGood:
+		p.Kicking.ExtraPointAttempts++
+		p.Kicking.ExtraPointsMade++
+	case StatIDExtraPointFailed:
+		p.Kicking.ExtraPointAttempts++
+	case StatIDExtraPointBlocked:
+		p.Kicking.ExtraPointAttempts++
+		p.Kicking.ExtraPointsBlocked++
+	// Each kickoff has exactly one of these, which carry its yardage. Inside 20 is an additional
+	// flag.
+	case StatIDKickoffYards, StatIDKickoffIntoEndZone, StatIDKickoffWithTouchback:
+		p.Kicking.Kickoffs++
+		p.Kicking.KickoffYards += yards
+		if stat.StatID == StatIDKickoffWithTouchback {
+			p.Kicking.KickoffTouchbacks++
+		}
+
+	// Punting. Like kickoffs, each punt has exactly one of these. Inside 20 is an additional flag.
+	case StatIDPuntingYards, StatIDPuntIntoEndZone, StatIDPuntWithTouchback:
+		p.Punting.Punts++
+		p.Punting.Yards += yards
+		p.Punting.Long = longest(p.Punting.Long, p.Punting.Punts-p.Punting.Blocked, yards)
+		if stat.StatID == StatIDPuntWithTouchback {
+			p.Punting.Touchbacks++
+		}
+	case StatIDPuntInside20:
+		p.Punting.Inside20++
+	case StatIDPuntBlocked:
+		p.Punting.Punts++
+		p.Punting.Blocked++
+
+	// Returns
+	case StatIDPuntReturnYards, StatIDPuntReturnYardsTD:
+		p.PuntReturns.Returns++
+		p.PuntReturns.Yards += yards
+		p.PuntReturns.Long = longest(p.PuntReturns.Long, p.PuntReturns.Returns, yards)
+		if stat.StatID == StatIDPuntReturnYardsTD {
+			p.PuntReturns.Touchdowns++
+		}
+	case StatIDPuntReturnYardsNoReturn, StatIDPuntReturnYardsTDNoReturn:
+		p.PuntReturns.Yards += yards
+		if stat.StatID == StatIDPuntReturnYardsTDNoReturn {
+			p.PuntReturns.Touchdowns++
+		}
+	case StatIDPuntFairCatch:
+		p.PuntReturns.FairCatches++
+	case StatIDKickoffReturnYards, StatIDKickoffReturnYardsTD:
+		p.KickReturns.Returns++
+		p.KickReturns.Yards += yards
+		p.KickReturns.Long = longest(p.KickReturns.Long, p.KickReturns.Returns, yards)
+		if stat.StatID == StatIDKickoffReturnYardsTD {
+			p.KickReturns.Touchdowns++
+		}
+	case StatIDKickoffReturnYardsNoReturn, StatIDKickoffReturnYardsTDNoReturn:
+		p.KickReturns.Yards += yards
+		if stat.StatID == StatIDKickoffReturnYardsTDNoReturn {
+			p.KickReturns.Touchdowns++
+		}
+	case StatIDKickoffFairCatch:
+		p.KickReturns.FairCatches++
+
+	// Fumbles
+	case StatIDFumbleForced, StatIDFumbleNotForced:
+		p.Fumbles.Fumbles++
+	case StatIDFumbleOutOfBounds:
+		// Always accompanied by one of the above.
+		p.Fumbles.OutOfBounds++
+	case StatIDFumbleLost:
+		p.Fumbles.Lost++
+	case StatIDOwnRecoveryYards, StatIDOwnRecoveryYardsTD:
+		p.Fumbles.OwnRecoveries++
+		p.Fumbles.OwnRecoveryYards += yards
+	}
+}
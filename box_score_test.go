@@ -0,0 +1,217 @@
+package gsis
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatFile_BoxScore(t *testing.T) {
+	buf, err := ioutil.ReadFile("testdata/signalr-stats.json")
+	require.NoError(t, err)
+
+	var stats StatFile
+	require.NoError(t, json.Unmarshal(buf, &stats))
+
+	// The feed includes its own per-player tables, which we use as the expected values.
+	var tables map[string][]map[string]interface{}
+	{
+		var raw map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(buf, &raw))
+		tables = map[string][]map[string]interface{}{}
+		for name, v := range raw {
+			var rows []map[string]interface{}
+			if json.Unmarshal(v, &rows) == nil {
+				tables[name] = rows
+			}
+		}
+	}
+
+	boxScore := stats.BoxScore()
+	require.NotEmpty(t, boxScore.Players)
+	assert.NotEmpty(t, boxScore.Team("DAL"))
+	assert.NotEmpty(t, boxScore.Team("LA"))
+	assert.Nil(t, boxScore.Player("00-0000000"))
+
+	forEachRow := func(table string, f func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64)) {
+		for _, prefix := range []string{"HPLAYER_", "VPLAYER_"} {
+			rows := tables[prefix+table]
+			for _, row := range rows {
+				playerID, _ := row["PlayerID"].(string)
+				if playerID == "" {
+					continue
+				}
+				p := boxScore.Player(playerID)
+				if !assert.NotNil(t, p, "%v %v", table, playerID) {
+					continue
+				}
+				f(p, row, func(field string) float64 {
+					n, err := strconv.ParseFloat(row[field].(string), 64)
+					require.NoError(t, err)
+					return n
+				})
+			}
+		}
+	}
+
+	forEachRow("PASS", func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64) {
+		assert.Equal(t, int(value("Attempts")), p.Passing.Attempts, p.PlayerName)
+		assert.Equal(t, int(value("Completions")), p.Passing.Completions, p.PlayerName)
+		assert.Equal(t, int(value("Yards")), p.Passing.Yards, p.PlayerName)
+		assert.Equal(t, int(value("Touchdowns")), p.Passing.Touchdowns, p.PlayerName)
+		assert.Equal(t, int(value("Interceptions")), p.Passing.Interceptions, p.PlayerName)
+		assert.Equal(t, int(value("TimesSacked")), p.Passing.Sacks, p.PlayerName)
+		assert.Equal(t, int(value("SackYardsLost")), p.Passing.SackYards, p.PlayerName)
+		assert.Equal(t, int(value("Long")), p.Passing.Long, p.PlayerName)
+	})
+
+	forEachRow("RUSH", func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64) {
+		assert.Equal(t, int(value("Attempts")), p.Rushing.Attempts, p.PlayerName)
+		assert.Equal(t, int(value("Yards")), p.Rushing.Yards, p.PlayerName)
+		assert.Equal(t, int(value("Touchdowns")), p.Rushing.Touchdowns, p.PlayerName)
+		assert.Equal(t, int(value("Long")), p.Rushing.Long, p.PlayerName)
+	})
+
+	forEachRow("RECV", func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64) {
+		assert.Equal(t, int(value("Receptions")), p.Receiving.Receptions, p.PlayerName)
+		assert.Equal(t, int(value("Yards")), p.Receiving.Yards, p.PlayerName)
+		assert.Equal(t, int(value("Touchdowns")), p.Receiving.Touchdowns, p.PlayerName)
+		assert.Equal(t, int(value("Long")), p.Receiving.Long, p.PlayerName)
+		assert.Equal(t, int(value("PassTarget")), p.Receiving.Targets, p.PlayerName)
+		assert.Equal(t, int(value("YardsAfterCatch")), p.Receiving.YardsAfterCatch, p.PlayerName)
+	})
+
+	forEachRow("DEFENSE", func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64) {
+		assert.Equal(t, int(value("Tackles")), p.Defense.Tackles, p.PlayerName)
+		assert.Equal(t, int(value("Assists")), p.Defense.Assists, p.PlayerName)
+		assert.Equal(t, value("Combined"), p.Defense.Combined(), p.PlayerName)
+		assert.Equal(t, value("Sacks"), p.Defense.Sacks, p.PlayerName)
+		assert.Equal(t, value("SackYards"), p.Defense.SackYards, p.PlayerName)
+		assert.Equal(t, int(value("PassDefences")), p.Defense.PassesDefensed, p.PlayerName)
+		assert.Equal(t, int(value("ForcedFumbles")), p.Defense.ForcedFumbles, p.PlayerName)
+		assert.Equal(t, int(value("QuarterbackHits")), p.Defense.QuarterbackHits, p.PlayerName)
+		assert.Equal(t, value("TacklesForALoss"), p.Defense.TacklesForLoss, p.PlayerName)
+		assert.Equal(t, int(value("TacklesForALossYards")), p.Defense.TacklesForLossYards, p.PlayerName)
+		assert.Equal(t, int(value("SpecialTeamsTackles")), p.SpecialTeams.Tackles, p.PlayerName)
+		assert.Equal(t, int(value("SpecialTeamsAssists")), p.SpecialTeams.Assists, p.PlayerName)
+		assert.Equal(t, int(value("SpecialTeamsBlocks")), p.SpecialTeams.Blocks, p.PlayerName)
+		assert.Equal(t, int(value("MiscellaneousTackles")), p.Miscellaneous.Tackles, p.PlayerName)
+		assert.Equal(t, int(value("MiscellaneousAssists")), p.Miscellaneous.Assists, p.PlayerName)
+	})
+
+	forEachRow("FG", func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64) {
+		assert.Equal(t, int(value("FieldGoalAttempts")), p.Kicking.FieldGoalAttempts, p.PlayerName)
+		assert.Equal(t, int(value("FieldGoalsMade")), p.Kicking.FieldGoalsMade, p.PlayerName)
+		assert.Equal(t, int(value("FieldGoalsBlocked")), p.Kicking.FieldGoalsBlocked, p.PlayerName)
+		assert.Equal(t, int(value("LongestMadeFieldGoal")), p.Kicking.LongFieldGoal, p.PlayerName)
+	})
+
+	forEachRow("PAT", func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64) {
+		assert.Equal(t, int(value("PATAttempts")), p.Kicking.ExtraPointAttempts, p.PlayerName)
+		assert.Equal(t, int(value("PATsMade")), p.Kicking.ExtraPointsMade, p.PlayerName)
+		assert.Equal(t, int(value("PATsBlocked")), p.Kicking.ExtraPointsBlocked, p.PlayerName)
+	})
+
+	forEachRow("KICKOFF", func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64) {
+		assert.Equal(t, int(value("Kickoffs")), p.Kicking.Kickoffs, p.PlayerName)
+		assert.Equal(t, int(value("Yards")), p.Kicking.KickoffYards, p.PlayerName)
+		assert.Equal(t, int(value("Touchbacks")), p.Kicking.KickoffTouchbacks, p.PlayerName)
+	})
+
+	forEachRow("PUNT", func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64) {
+		assert.Equal(t, int(value("Punts")), p.Punting.Punts, p.PlayerName)
+		assert.Equal(t, int(value("PuntYards")), p.Punting.Yards, p.PlayerName)
+		assert.Equal(t, int(value("Longest")), p.Punting.Long, p.PlayerName)
+		assert.Equal(t, int(value("Inside20")), p.Punting.Inside20, p.PlayerName)
+		assert.Equal(t, int(value("Touchbacks")), p.Punting.Touchbacks, p.PlayerName)
+		assert.Equal(t, int(value("BlockedPunts")), p.Punting.Blocked, p.PlayerName)
+	})
+
+	forEachRow("KICKRET", func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64) {
+		assert.Equal(t, int(value("Number")), p.KickReturns.Returns, p.PlayerName)
+		assert.Equal(t, int(value("Yards")), p.KickReturns.Yards, p.PlayerName)
+		assert.Equal(t, int(value("Touchdowns")), p.KickReturns.Touchdowns, p.PlayerName)
+		assert.Equal(t, int(value("Longest")), p.KickReturns.Long, p.PlayerName)
+		assert.Equal(t, int(value("FairCatches")), p.KickReturns.FairCatches, p.PlayerName)
+	})
+
+	forEachRow("PUNTRET", func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64) {
+		assert.Equal(t, int(value("Number")), p.PuntReturns.Returns, p.PlayerName)
+		assert.Equal(t, int(value("Yards")), p.PuntReturns.Yards, p.PlayerName)
+		assert.Equal(t, int(value("Touchdowns")), p.PuntReturns.Touchdowns, p.PlayerName)
+		assert.Equal(t, int(value("Longest")), p.PuntReturns.Long, p.PlayerName)
+		assert.Equal(t, int(value("FairCatches")), p.PuntReturns.FairCatches, p.PlayerName)
+	})
+
+	forEachRow("INTERCEPTION", func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64) {
+		assert.Equal(t, int(value("Number")), p.Defense.Interceptions, p.PlayerName)
+		assert.Equal(t, int(value("Yards")), p.Defense.InterceptionYards, p.PlayerName)
+		assert.Equal(t, int(value("Touchdowns")), p.Defense.InterceptionTouchdowns, p.PlayerName)
+	})
+
+	forEachRow("FUMBLE", func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64) {
+		assert.Equal(t, int(value("Fumbles")), p.Fumbles.Fumbles, p.PlayerName)
+		assert.Equal(t, int(value("FumblesLost")), p.Fumbles.Lost, p.PlayerName)
+		assert.Equal(t, int(value("OwnFumbleRecoveries")), p.Fumbles.OwnRecoveries, p.PlayerName)
+		assert.Equal(t, int(value("OpponentFumbleRecoveries")), p.Defense.FumbleRecoveries, p.PlayerName)
+	})
+
+	forEachRow("2PTPASS", func(p *PlayerBoxScore, row map[string]interface{}, value func(string) float64) {
+		assert.Equal(t, int(value("TwoPointPassSuccesses")), p.Passing.TwoPointConversions, p.PlayerName)
+	})
+}
+
+func TestStatFile_BoxScore_Splits(t *testing.T) {
+	yards := func(n int) StatYards {
+		return StatYards{Value: &n}
+	}
+
+	stats := StatFile{
+		Play: []*StatFilePlay{
+			{PlayID: 1, PossessionTeam: "SEA"},
+			{PlayID: 2, PossessionTeam: "SEA", PlayDeleted: 1},
+		},
+		PlayStat: []StatFilePlayStat{
+			{PlayID: 1, StatID: StatIDSackYards, ClubCode: "SEA", PlayerID: "qb", Yards: yards(-8)},
+			{PlayID: 1, StatID: StatIDHalfSackYardsDefense, ClubCode: "SF", PlayerID: "a", Yards: yards(-4)},
+			{PlayID: 1, StatID: StatIDHalfSackYardsDefense, ClubCode: "SF", PlayerID: "b", Yards: yards(-4)},
+			{PlayID: 1, StatID: StatIDHalfTackle, ClubCode: "SF", PlayerID: "a"},
+			{PlayID: 1, StatID: StatIDHalfTackle, ClubCode: "SF", PlayerID: "b"},
+			{PlayID: 1, StatID: StatIDHalfTackleForLoss, ClubCode: "SF", PlayerID: "a"},
+			{PlayID: 1, StatID: StatIDHalfTackleForLoss, ClubCode: "SF", PlayerID: "b"},
+			{PlayID: 1, StatID: StatIDRushingYards, ClubCode: "SEA", PlayerID: "rb", Yards: yards(5)},
+			{PlayID: 1, StatID: StatIDRushingYardsMinus, ClubCode: "SEA", PlayerID: "rb", Yards: yards(-3)},
+			{PlayID: 2, StatID: StatIDSackYardsDefense, ClubCode: "SF", PlayerID: "a", Yards: yards(-10)},
+			{PlayID: 2, StatID: StatIDSoloTackle, ClubCode: "SF", PlayerID: "c"},
+		},
+	}
+
+	boxScore := stats.BoxScore()
+	require.Len(t, boxScore.Players, 4)
+	assert.Nil(t, boxScore.Player("c"))
+
+	qb := boxScore.Player("qb")
+	require.NotNil(t, qb)
+	assert.Equal(t, 1, qb.Passing.Sacks)
+	assert.Equal(t, 8, qb.Passing.SackYards)
+	assert.Equal(t, 0, qb.Passing.Attempts)
+
+	rb := boxScore.Player("rb")
+	require.NotNil(t, rb)
+	assert.Equal(t, 1, rb.Rushing.Attempts)
+	assert.Equal(t, 2, rb.Rushing.Yards)
+
+	for _, id := range []string{"a", "b"} {
+		p := boxScore.Player(id)
+		require.NotNil(t, p)
+		assert.Equal(t, 0.5, p.Defense.Sacks)
+		assert.Equal(t, 4.0, p.Defense.SackYards)
+		assert.Equal(t, 0.5, p.Defense.TacklesForLoss)
+		assert.Equal(t, 1, p.Defense.HalfTackles)
+		assert.Equal(t, 0.5, p.Defense.Combined())
+	}
+}
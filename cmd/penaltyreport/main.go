@@ -0,0 +1,96 @@
+// Command penaltyreport reports the penalty phrases the parser doesn't understand across a
+// directory of stat files. Files ending in .xml are read as GSISGameStats XML, and files ending in
+// .json as SignalR stat payloads.
+//
+//	penaltyreport testdata/games
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sportsball-ai/gsis"
+)
+
+func main() {
+	examples := flag.Bool("examples", false, "print an example play description for each phrase")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] dir...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	report := gsis.NewPenaltyReport()
+	files := 0
+	for _, dir := range flag.Args() {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			var unmarshal func([]byte, interface{}) error
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".xml":
+				unmarshal = xml.Unmarshal
+			case ".json":
+				unmarshal = json.Unmarshal
+			default:
+				return nil
+			}
+			buf, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			var f gsis.StatFile
+			if err := unmarshal(buf, &f); err != nil {
+				fmt.Fprintf(os.Stderr, "skipping %v: %v\n", path, err)
+				return nil
+			}
+			if len(f.Play) == 0 {
+				return nil
+			}
+			report.AddStatFile(&f)
+			files++
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Printf("%d files, %d plays with penalties, %d penalties\n", files, report.Plays, report.Penalties)
+
+	warnings := make([]string, 0, len(report.Warnings))
+	for w := range report.Warnings {
+		warnings = append(warnings, string(w))
+	}
+	sort.Strings(warnings)
+	if len(warnings) > 0 {
+		fmt.Println("\nwarnings:")
+	}
+	for _, w := range warnings {
+		fmt.Printf("%8d  %v\n", report.Warnings[gsis.PenaltyWarning(w)], w)
+	}
+
+	phrases := report.UnparsedPhrases()
+	if len(phrases) > 0 {
+		fmt.Println("\nunparsed phrases:")
+	}
+	for _, p := range phrases {
+		fmt.Printf("%8d  %v\n", p.Count, p.Phrase)
+		if *examples {
+			fmt.Printf("          %v\n", p.Example)
+		}
+	}
+}
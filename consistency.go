@@ -0,0 +1,325 @@
+package gsis
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// A disagreement between a total reported by GSIS and the same total recomputed from the plays.
+type StatFileDiscrepancy struct {
+	ClubCode string
+
+	// The name of the reported attribute, such as "RushingYards" or "HomeFGAttempts".
+	Stat string
+
+	// Time of possession is in seconds.
+	Reported int
+	Computed int
+
+	// The plays that contributed to the computed total, in file order.
+	PlayIDs []int
+}
+
+func (d *StatFileDiscrepancy) String() string {
+	return fmt.Sprintf("%v %v: reported %v, computed %v (plays %v)", d.ClubCode, d.Stat, d.Reported, d.Computed, d.PlayIDs)
+}
+
+type statFileTotal struct {
+	value   int
+	playIDs []int
+}
+
+type statFileTotals map[string]*statFileTotal
+
+func (t statFileTotals) add(stat string, playID StringInt, n int) {
+	total, ok := t[stat]
+	if !ok {
+		total = &statFileTotal{}
+		t[stat] = total
+	}
+	total.value += n
+	if len(total.playIDs) == 0 || total.playIDs[len(total.playIDs)-1] != int(playID) {
+		total.playIDs = append(total.playIDs, int(playID))
+	}
+}
+
+func (t statFileTotals) get(stat string) *statFileTotal {
+	if total, ok := t[stat]; ok {
+		return total
+	}
+	return &statFileTotal{}
+}
+
+// Returns the points scored by a stat, along with a key used to avoid counting the same score
+// twice when it's credited to multiple players.
+func statPoints(id StatID) (string, int) {
+	switch {
+	case id.IsTouchdown():
+		return "TD", 6
+	case id == StatIDFieldGoalYards:
+		return "FG", 3
+	case id == StatIDExtraPointGood:
+		return "XP", 1
+	case id == StatID2PointRushGood, id == StatID2PointPassGood, id == StatID2PointPassReceptionGood:
+		return "2PT", 2
+	case id == StatID2PointReturnGood, id == StatIDDefensive2PointConversions:
+		return "D2PT", 2
+	case id == StatIDSafetyDefense, id == StatIDHalfSafetyDefense:
+		return "SAFETY", 2
+	}
+	return "", 0
+}
+
+// The maximum difference in seconds between the reported and computed time of possession that
+// CheckConsistency tolerates.
+const TimeOfPossessionTolerance = 60
+
+// CheckConsistency recomputes team totals from the play stats and compares them to the totals
+// reported in HomeTeamStats, VisitorTeamStats, FieldGoals, and Punts. It returns a discrepancy
+// for each total that doesn't match. Totals that aren't present in the file aren't checked.
+//
+// Discrepancies are expected while a game is in progress, as GSIS doesn't update every section
+// of the file at the same time.
+func (f *StatFile) CheckConsistency() []*StatFileDiscrepancy {
+	if f.CumeStatHeader == nil {
+		return nil
+	}
+	homeClubCode := f.CumeStatHeader.HomeClubCode
+	visitorClubCode := f.CumeStatHeader.VisitorClubCode
+
+	plays := map[StringInt]*StatFilePlay{}
+	for _, p := range f.Play {
+		if p.PlayDeleted == 0 {
+			plays[p.PlayID] = p
+		}
+	}
+
+	totals := map[string]statFileTotals{
+		homeClubCode:    {},
+		visitorClubCode: {},
+	}
+	scored := map[string]bool{}
+
+	for _, stat := range f.PlayStat {
+		play, ok := plays[stat.PlayID]
+		if !ok {
+			continue
+		}
+		t, ok := totals[stat.ClubCode]
+		if !ok {
+			continue
+		}
+		yards := stat.Yards.Int()
+
+		switch stat.StatID {
+		case StatIDRushingYards, StatIDRushingYardsTD:
+			t.add("RushingPlays", stat.PlayID, 1)
+			t.add("RushingYards", stat.PlayID, yards)
+			t.add("TotalYards", stat.PlayID, yards)
+		case StatIDRushingYardsNoRush, StatIDRushingYardsTDNoRush:
+			t.add("RushingYards", stat.PlayID, yards)
+			t.add("TotalYards", stat.PlayID, yards)
+		case StatIDPassIncomplete:
+			t.add("PassingAttempts", stat.PlayID, 1)
+		case StatIDPassingYards, StatIDPassingYardsTD:
+			t.add("PassingAttempts", stat.PlayID, 1)
+			t.add("PassingCompletions", stat.PlayID, 1)
+			t.add("PassingYards", stat.PlayID, yards)
+			t.add("TotalYards", stat.PlayID, yards)
+		case StatIDPassingYardsNoPass, StatIDPassingYardsTDNoPass:
+			t.add("PassingYards", stat.PlayID, yards)
+			t.add("TotalYards", stat.PlayID, yards)
+		case StatIDInterceptionPasser:
+			t.add("PassingAttempts", stat.PlayID, 1)
+			t.add("Interceptions", stat.PlayID, 1)
+			t.add("Turnovers", stat.PlayID, 1)
+		case StatIDSackYards, StatIDSackYardsNoSack:
+			// Team passing yards are net of sacks.
+			t.add("PassingYards", stat.PlayID, yards)
+			t.add("TotalYards", stat.PlayID, yards)
+		case StatIDFirstDownRushing:
+			t.add("RushingFirstDowns", stat.PlayID, 1)
+		case StatIDFirstDownPassing:
+			t.add("PassingFirstDowns", stat.PlayID, 1)
+		case StatIDFirstDownPenalty:
+			t.add("FirstDownsByPenalty", stat.PlayID, 1)
+		case StatIDPenalty:
+			t.add("Penalties", stat.PlayID, 1)
+			t.add("PenaltyYards", stat.PlayID, yards)
+		case StatIDFumbleForced, StatIDFumbleNotForced:
+			// Fumbles out of bounds are also credited one of these.
+			t.add("Fumbles", stat.PlayID, 1)
+		case StatIDFumbleLost:
+			t.add("LostFumbles", stat.PlayID, 1)
+			t.add("Turnovers", stat.PlayID, 1)
+		case StatIDFieldGoalMissedYards, StatIDFieldGoalBlockedOffense:
+			t.add("FGAttempts", stat.PlayID, 1)
+		case StatIDFieldGoalYards:
+			t.add("FGAttempts", stat.PlayID, 1)
+			t.add("FGMade", stat.PlayID, 1)
+		case StatIDPuntingYards, StatIDPuntIntoEndZone, StatIDPuntWithTouchback:
+			t.add("Punts", stat.PlayID, 1)
+			t.add("PuntYards", stat.PlayID, yards)
+		case StatIDPuntBlocked:
+			t.add("Punts", stat.PlayID, 1)
+		}
+
+		if stat.StatID.IsFirstDown() {
+			t.add("TotalFirstDowns", stat.PlayID, 1)
+		}
+		if stat.StatID.IsTouchdown() {
+			switch stat.StatID.Category() {
+			case StatCategoryRushing:
+				t.add("RushingTDs", stat.PlayID, 1)
+			case StatCategoryPassing:
+				t.add("PassingTDs", stat.PlayID, 1)
+			}
+		}
+
+		if kind, points := statPoints(stat.StatID); points > 0 {
+			key := fmt.Sprintf("%v/%v/%v", stat.PlayID, stat.ClubCode, kind)
+			if !scored[key] {
+				scored[key] = true
+				quarter := "OTScore"
+				if play.Quarter >= 1 && play.Quarter <= 4 {
+					quarter = fmt.Sprintf("Q%dScore", play.Quarter)
+				}
+				t.add(quarter, stat.PlayID, points)
+				t.add("TotalScore", stat.PlayID, points)
+			}
+		}
+	}
+
+	for clubCode, total := range timeOfPossession(f.Play) {
+		if t, ok := totals[clubCode]; ok {
+			t["TimeOfPossession"] = total
+		}
+	}
+
+	var ret []*StatFileDiscrepancy
+	checkWithTolerance := func(clubCode, stat string, reported StringInt, computed string, tolerance int) {
+		total := totals[clubCode].get(computed)
+		if diff := int(reported) - total.value; diff > tolerance || diff < -tolerance {
+			ret = append(ret, &StatFileDiscrepancy{
+				ClubCode: clubCode,
+				Stat:     stat,
+				Reported: int(reported),
+				Computed: total.value,
+				PlayIDs:  total.playIDs,
+			})
+		}
+	}
+	check := func(clubCode, stat string, reported StringInt, computed string) {
+		checkWithTolerance(clubCode, stat, reported, computed, 0)
+	}
+
+	checkTeamStats := func(clubCode string, s *StatFileTeamStats) {
+		if s == nil {
+			return
+		}
+		check(clubCode, "RushingPlays", s.RushingPlays, "RushingPlays")
+		check(clubCode, "RushingYards", s.RushingYards, "RushingYards")
+		check(clubCode, "RushingFirstDowns", s.RushingFirstDowns, "RushingFirstDowns")
+		check(clubCode, "RushingTDs", s.RushingTDs, "RushingTDs")
+		check(clubCode, "PassingAttempts", s.PassingAttempts, "PassingAttempts")
+		check(clubCode, "PassingCompletions", s.PassingCompletions, "PassingCompletions")
+		check(clubCode, "PassingYards", s.PassingYards, "PassingYards")
+		check(clubCode, "PassingFirstDowns", s.PassingFirstDowns, "PassingFirstDowns")
+		check(clubCode, "PassingTDs", s.PassingTDs, "PassingTDs")
+		check(clubCode, "TotalYards", s.TotalYards, "TotalYards")
+		check(clubCode, "FirstDownsByPenalty", s.FirstDownsByPenalty, "FirstDownsByPenalty")
+		check(clubCode, "TotalFirstDowns", s.TotalFirstDowns, "TotalFirstDowns")
+		check(clubCode, "Penalties", s.Penalties, "Penalties")
+		check(clubCode, "PenaltyYards", s.PenaltyYards, "PenaltyYards")
+		check(clubCode, "Fumbles", s.Fumbles, "Fumbles")
+		check(clubCode, "LostFumbles", s.LostFumbles, "LostFumbles")
+		check(clubCode, "Interceptions", s.Interceptions, "Interceptions")
+		check(clubCode, "Turnovers", s.Turnovers, "Turnovers")
+		check(clubCode, "Q1Score", s.Q1Score, "Q1Score")
+		check(clubCode, "Q2Score", s.Q2Score, "Q2Score")
+		check(clubCode, "Q3Score", s.Q3Score, "Q3Score")
+		check(clubCode, "Q4Score", s.Q4Score, "Q4Score")
+		check(clubCode, "OTScore", s.OTScore, "OTScore")
+		check(clubCode, "TotalScore", s.TotalScore, "TotalScore")
+		if !s.TimeOfPossession.IsNil() {
+			// The plays don't say when possession changes during a play, such as on a punt return, so
+			// time of possession can only be approximated.
+			checkWithTolerance(clubCode, "TimeOfPossession", StringInt(s.TimeOfPossession.Duration()/time.Second), "TimeOfPossession", TimeOfPossessionTolerance)
+		}
+	}
+	checkTeamStats(homeClubCode, f.HomeTeamStats)
+	checkTeamStats(visitorClubCode, f.VisitorTeamStats)
+
+	if fg := f.FieldGoals; fg != nil {
+		check(homeClubCode, "HomeFGAttempts", fg.HomeFGAttempts, "FGAttempts")
+		check(homeClubCode, "HomeFGMade", fg.HomeFGMade, "FGMade")
+		check(visitorClubCode, "VisitorFGAttempts", fg.VisitorFGAttempts, "FGAttempts")
+		check(visitorClubCode, "VisitorFGMade", fg.VisitorFGMade, "FGMade")
+	}
+
+	if punts := f.Punts; punts != nil {
+		check(homeClubCode, "HomePunts", punts.HomePunts, "Punts")
+		check(homeClubCode, "HomePuntYards", punts.HomePuntYards, "PuntYards")
+		check(visitorClubCode, "VisitorPunts", punts.VisitorPunts, "Punts")
+		check(visitorClubCode, "VisitorPuntYards", punts.VisitorPuntYards, "PuntYards")
+	}
+
+	return ret
+}
+
+// Computes each team's time of possession in seconds from the game clock at the start of each
+// play. The time between the snaps of two consecutive plays is credited to the team in possession
+// for the first one, and the time remaining after the last play of a quarter is credited to the
+// team in possession for it. Free kicks are credited to the receiving team.
+func timeOfPossession(allPlays []*StatFilePlay) map[string]*statFileTotal {
+	// The plays and end of quarter markers with clock times. Games that end early, such as in
+	// overtime, have the clock time of the end in the END GAME play.
+	var plays []*StatFilePlay
+	teams := map[string]bool{}
+	for _, p := range allPlays {
+		if p.PlayDeleted != 0 || p.ClockTime.IsNil() {
+			continue
+		}
+		switch p.PlayType {
+		case PlayTypeEndQuarter, PlayTypeEndGame:
+			plays = append(plays, p)
+		case PlayTypeGame, PlayTypeComment, PlayTypeTimeout:
+		default:
+			if p.PossessionTeam != "" {
+				plays = append(plays, p)
+				teams[strings.Trim(p.PossessionTeam, `"`)] = true
+			}
+		}
+	}
+
+	ret := map[string]*statFileTotal{}
+	for i, p := range plays {
+		if !p.IsActualPlay() {
+			continue
+		}
+
+		team := strings.Trim(p.PossessionTeam, `"`)
+		if p.PlayType == PlayTypeFreeKick {
+			for other := range teams {
+				if other != team {
+					team = other
+				}
+			}
+		}
+
+		elapsed := p.ClockTime.Duration()
+		if i+1 < len(plays) && plays[i+1].Quarter == p.Quarter {
+			elapsed -= plays[i+1].ClockTime.Duration()
+		}
+
+		total, ok := ret[team]
+		if !ok {
+			total = &statFileTotal{}
+			ret[team] = total
+		}
+		total.value += int(elapsed / time.Second)
+		total.playIDs = append(total.playIDs, int(p.PlayID))
+	}
+	return ret
+}
@@ -0,0 +1,62 @@
+package gsis
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatFile_CheckConsistency(t *testing.T) {
+	f, err := os.Open("testdata/signalr-stats.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	var stats StatFile
+	require.NoError(t, json.NewDecoder(f).Decode(&stats))
+	assert.Empty(t, stats.CheckConsistency())
+
+	// Drop a rushing play and make sure it's noticed.
+	var playID StringInt
+	n := 0
+	for _, stat := range stats.PlayStat {
+		if playID == 0 && stat.StatID == StatIDRushingYards && stat.ClubCode == stats.CumeStatHeader.HomeClubCode {
+			playID = stat.PlayID
+		}
+		if stat.PlayID != playID {
+			stats.PlayStat[n] = stat
+			n++
+		}
+	}
+	stats.PlayStat = stats.PlayStat[:n]
+
+	discrepancies := map[string]*StatFileDiscrepancy{}
+	for _, d := range stats.CheckConsistency() {
+		assert.Equal(t, stats.CumeStatHeader.HomeClubCode, d.ClubCode)
+		assert.NotContains(t, d.PlayIDs, int(playID))
+		discrepancies[d.Stat] = d
+	}
+	require.Contains(t, discrepancies, "RushingPlays")
+	assert.Equal(t, int(stats.HomeTeamStats.RushingPlays)-1, discrepancies["RushingPlays"].Computed)
+	assert.Contains(t, discrepancies, "RushingYards")
+	assert.Contains(t, discrepancies, "TotalYards")
+}
+
+func TestStatFile_CheckConsistency_Games(t *testing.T) {
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		discrepancies := stats.CheckConsistency()
+
+		// Some of the files are incremental updates that only contain a few plays.
+		complete := false
+		for _, p := range stats.Play {
+			if p.PlayType == PlayTypeEndGame {
+				complete = true
+			}
+		}
+		if complete {
+			assert.Empty(t, discrepancies)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package gsis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// The length of a clip for a play with no known end, before post-roll.
+const DefaultCueDuration = 10 * time.Second
+
+type CueListOptions struct {
+	// The wall-clock time at the start of the video. If zero, the video is assumed to start at
+	// the first play.
+	VideoStart time.Time
+
+	// Added to every cue's position in the video, e.g. to account for a pregame show.
+	Offset time.Duration
+
+	// How much video to include before the snap and after the play ends.
+	PreRoll  time.Duration
+	PostRoll time.Duration
+}
+
+// A clip of a single play.
+type Cue struct {
+	Play *StatFilePlay
+
+	// The clip's position in the video.
+	Start time.Duration
+	End   time.Duration
+
+	Clock   GameClock
+	Scoring bool
+}
+
+// Label returns a short description of the play, e.g. "Q1 14:43 J.Goff pass short left ...".
+// Scoring plays are marked.
+func (c *Cue) Label() string {
+	var parts []string
+	if !c.Play.ClockTime.IsNil() {
+		parts = append(parts, c.Clock.String())
+	} else if c.Clock.Quarter > 0 {
+		parts = append(parts, strings.Fields(c.Clock.String())[0])
+	}
+	if c.Scoring {
+		parts = append(parts, "[SCORE]")
+	}
+	parts = append(parts, strings.Join(strings.Fields(c.Play.PlayDescription), " "))
+	return strings.Join(parts, " ")
+}
+
+// Cues returns a clip for each actual play, in order. Deleted plays and things like commentary are
+// excluded. Each clip starts at the snap and ends at the start of the next play, plus pre-roll and
+// post-roll. It returns nil if the plays have no times of day.
+func (f *StatFile) Cues(opts CueListOptions) []*Cue {
+	timestamps := f.PlayTimestamps()
+	if len(timestamps) == 0 {
+		return nil
+	}
+
+	videoStart := opts.VideoStart
+	if videoStart.IsZero() {
+		for _, ts := range timestamps {
+			if ts.Play.IsActualPlay() {
+				videoStart = ts.Start
+				break
+			}
+		}
+	}
+
+	seasonType := ""
+	if f.CumeStatHeader != nil {
+		seasonType = f.CumeStatHeader.SeasonType
+	}
+
+	var ret []*Cue
+	for _, ts := range timestamps {
+		if !ts.Play.IsActualPlay() {
+			continue
+		}
+		end := ts.End
+		if end.IsZero() || !end.After(ts.Start) {
+			end = ts.Start.Add(DefaultCueDuration)
+		}
+		cue := &Cue{
+			Play:    ts.Play,
+			Start:   ts.Start.Sub(videoStart) + opts.Offset - opts.PreRoll,
+			End:     end.Sub(videoStart) + opts.Offset + opts.PostRoll,
+			Clock:   NewGameClock(int(ts.Play.Quarter), ts.Play.ClockTime.Duration(), seasonType),
+			Scoring: bool(ts.Play.IsScoringPlay),
+		}
+		if cue.Start < 0 {
+			cue.Start = 0
+		}
+		if cue.End <= cue.Start {
+			continue
+		}
+		ret = append(ret, cue)
+	}
+	return ret
+}
+
+// Formats the duration as an SMPTE non-drop-frame timecode.
+func edlTimecode(d time.Duration, frameRate int) string {
+	frames := int64(d) * int64(frameRate) / int64(time.Second)
+	fps := int64(frameRate)
+	return fmt.Sprintf("%02d:%02d:%02d:%02d", frames/(fps*3600), frames/(fps*60)%60, frames/fps%60, frames%fps)
+}
+
+// WriteEDL writes the cues as a CMX 3600 edit decision list. Each cue becomes an event cut from
+// the source video and placed one after another in the record timeline.
+func WriteEDL(w io.Writer, title string, cues []*Cue, frameRate int) error {
+	if frameRate <= 0 {
+		return fmt.Errorf("invalid frame rate: %v", frameRate)
+	}
+	if _, err := fmt.Fprintf(w, "TITLE: %v\nFCM: NON-DROP FRAME\n\n", title); err != nil {
+		return err
+	}
+	var record time.Duration
+	for i, c := range cues {
+		length := c.End - c.Start
+		if _, err := fmt.Fprintf(w, "%03d  AX       V     C        %v %v %v %v\n* FROM CLIP NAME: PLAY %v\n* COMMENT: %v\n\n",
+			i+1,
+			edlTimecode(c.Start, frameRate), edlTimecode(c.End, frameRate),
+			edlTimecode(record, frameRate), edlTimecode(record+length, frameRate),
+			int(c.Play.PlayID), c.Label(),
+		); err != nil {
+			return err
+		}
+		record += length
+	}
+	return nil
+}
+
+type cueListEntry struct {
+	PlayID      int
+	Start       float64
+	End         float64
+	Quarter     int
+	Clock       string
+	Scoring     bool
+	Description string
+	Label       string
+}
+
+// WriteCueListJSON writes the cues as a JSON array. Start and end times are in seconds from the
+// start of the video.
+func WriteCueListJSON(w io.Writer, cues []*Cue) error {
+	entries := make([]cueListEntry, len(cues))
+	for i, c := range cues {
+		clock := ""
+		if !c.Play.ClockTime.IsNil() {
+			clock = formatGameClock(c.Clock.Clock)
+		}
+		entries[i] = cueListEntry{
+			PlayID:      int(c.Play.PlayID),
+			Start:       c.Start.Seconds(),
+			End:         c.End.Seconds(),
+			Quarter:     c.Clock.Quarter,
+			Clock:       clock,
+			Scoring:     c.Scoring,
+			Description: c.Play.PlayDescription,
+			Label:       c.Label(),
+		}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
+
+var webVTTEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func webVTTTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// WriteWebVTT writes the cues as WebVTT chapters. Chapters can't overlap, so each one ends no later
+// than the start of the next.
+func WriteWebVTT(w io.Writer, cues []*Cue) error {
+	if _, err := io.WriteString(w, "WEBVTT\n"); err != nil {
+		return err
+	}
+	for i, c := range cues {
+		end := c.End
+		if i+1 < len(cues) && cues[i+1].Start < end {
+			end = cues[i+1].Start
+		}
+		if end <= c.Start {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "\n%v\n%v --> %v\n%v\n", int(c.Play.PlayID), webVTTTimestamp(c.Start), webVTTTimestamp(end), webVTTEscaper.Replace(c.Label())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
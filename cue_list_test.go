@@ -0,0 +1,131 @@
+package gsis
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatFile_Cues(t *testing.T) {
+	buf, err := ioutil.ReadFile("testdata/signalr-stats.json")
+	require.NoError(t, err)
+	var stats StatFile
+	require.NoError(t, json.Unmarshal(buf, &stats))
+
+	cues := stats.Cues(CueListOptions{
+		VideoStart: time.Date(2019, 12, 15, 21, 20, 0, 0, time.UTC),
+		PreRoll:    3 * time.Second,
+		PostRoll:   2 * time.Second,
+	})
+	require.NotEmpty(t, cues)
+	for _, c := range cues {
+		assert.True(t, c.Play.IsActualPlay())
+		assert.True(t, c.End > c.Start)
+	}
+
+	// The kickoff at 21:25:18 is followed by a pass at 21:26:22.
+	kickoff := cues[0]
+	assert.Equal(t, 36, int(kickoff.Play.PlayID))
+	assert.Equal(t, 5*time.Minute+15*time.Second, kickoff.Start)
+	assert.Equal(t, 6*time.Minute+24*time.Second, kickoff.End)
+	assert.Equal(t, "Q1 15:00 K.Forbath kicks 61 yards from DAL 35 to LA 4, out of bounds.", kickoff.Label())
+
+	var touchdown *Cue
+	for _, c := range cues {
+		if c.Play.PlayID == 1850 {
+			touchdown = c
+		}
+	}
+	require.NotNil(t, touchdown)
+	assert.True(t, touchdown.Scoring)
+	assert.Contains(t, touchdown.Label(), "Q2 2:00 [SCORE] ")
+
+	// Without a video start, the video starts at the first play.
+	cues = stats.Cues(CueListOptions{
+		Offset: time.Minute,
+	})
+	require.NotEmpty(t, cues)
+	assert.Equal(t, time.Minute, cues[0].Start)
+	assert.Equal(t, time.Minute+64*time.Second, cues[0].End)
+
+	assert.Nil(t, (&StatFile{}).Cues(CueListOptions{}))
+}
+
+func TestWriteCues(t *testing.T) {
+	play := func(id int, quarter int, clock, description string, scoring bool) *StatFilePlay {
+		p := &StatFilePlay{
+			PlayID:          StringInt(id),
+			Quarter:         StringInt(quarter),
+			PlayDescription: description,
+			IsScoringPlay:   StringBool(scoring),
+		}
+		require.NoError(t, p.ClockTime.unmarshal(clock))
+		return p
+	}
+	cues := []*Cue{
+		{
+			Play:  play(1850, 2, "02:00", "(2:00) E.Elliott up the middle for 1 yard, TOUCHDOWN.", true),
+			Start: 90*time.Second + 500*time.Millisecond,
+			End:   2 * time.Minute,
+			Clock: NewGameClock(2, 2*time.Minute, "Reg"),
+		},
+		{
+			Play:  play(1872, 2, "", "B.Maher extra point is GOOD, Center-L.Ladouceur, Holder-C.Jones.", false),
+			Start: 110 * time.Second,
+			End:   2*time.Minute + 30*time.Second,
+			Clock: NewGameClock(2, 0, "Reg"),
+		},
+	}
+	cues[0].Scoring = true
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteEDL(&buf, "DAL vs LA", cues, 30))
+	assert.Equal(t, `TITLE: DAL vs LA
+FCM: NON-DROP FRAME
+
+001  AX       V     C        00:01:30:15 00:02:00:00 00:00:00:00 00:00:29:15
+* FROM CLIP NAME: PLAY 1850
+* COMMENT: Q2 2:00 [SCORE] (2:00) E.Elliott up the middle for 1 yard, TOUCHDOWN.
+
+002  AX       V     C        00:01:50:00 00:02:30:00 00:00:29:15 00:01:09:15
+* FROM CLIP NAME: PLAY 1872
+* COMMENT: Q2 B.Maher extra point is GOOD, Center-L.Ladouceur, Holder-C.Jones.
+
+`, buf.String())
+	assert.Error(t, WriteEDL(&buf, "", cues, 0))
+
+	buf.Reset()
+	require.NoError(t, WriteWebVTT(&buf, cues))
+	assert.Equal(t, `WEBVTT
+
+1850
+00:01:30.500 --> 00:01:50.000
+Q2 2:00 [SCORE] (2:00) E.Elliott up the middle for 1 yard, TOUCHDOWN.
+
+1872
+00:01:50.000 --> 00:02:30.000
+Q2 B.Maher extra point is GOOD, Center-L.Ladouceur, Holder-C.Jones.
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteCueListJSON(&buf, cues))
+	var entries []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entries))
+	require.Len(t, entries, 2)
+	assert.Equal(t, map[string]interface{}{
+		"PlayID":      1850.0,
+		"Start":       90.5,
+		"End":         120.0,
+		"Quarter":     2.0,
+		"Clock":       "2:00",
+		"Scoring":     true,
+		"Description": "(2:00) E.Elliott up the middle for 1 yard, TOUCHDOWN.",
+		"Label":       "Q2 2:00 [SCORE] (2:00) E.Elliott up the middle for 1 yard, TOUCHDOWN.",
+	}, entries[0])
+	assert.Equal(t, "", entries[1]["Clock"])
+}
@@ -0,0 +1,284 @@
+package gsis
+
+import (
+	"sort"
+	"strings"
+)
+
+// Which team commits a foul.
+type FoulSide string
+
+const (
+	FoulSideOffense      FoulSide = "OFFENSE"
+	FoulSideDefense      FoulSide = "DEFENSE"
+	FoulSideSpecialTeams FoulSide = "SPECIAL_TEAMS"
+	FoulSideEither       FoulSide = "EITHER"
+)
+
+// Where a foul is normally enforced from.
+type FoulSpotType string
+
+const (
+	// The previous spot, e.g. for fouls before the snap.
+	FoulSpotTypePrevious FoulSpotType = "PREVIOUS"
+
+	// The spot of the foul, e.g. for defensive pass interference.
+	FoulSpotTypeSpot FoulSpotType = "SPOT"
+
+	// The basic spot for the kind of play, e.g. the previous spot for passes or the end of the run
+	// for runs.
+	FoulSpotTypeBasic FoulSpotType = "BASIC"
+
+	// The spot where the ball became dead, for fouls that usually happen after the play.
+	FoulSpotTypeDeadBall FoulSpotType = "DEAD_BALL"
+
+	// The foul is enforced on a kick, e.g. a kickoff out of bounds.
+	FoulSpotTypeKick FoulSpotType = "KICK"
+)
+
+// The rules for a foul, as they're applied by default.
+type FoulRule struct {
+	Code FoulCode
+
+	// The canonical description, and any other descriptions GSIS uses for the foul. These are all
+	// lower case.
+	Description string
+	Aliases     []string
+
+	// The default yardage. Zero means the yardage depends on the play, e.g. the spot of the foul.
+	Yards int
+
+	// The yardage for fouls by the defense, if it's different.
+	DefenseYards int
+
+	Spot FoulSpotType
+	Side FoulSide
+
+	// Only applies to fouls by the defense.
+	AutomaticFirstDown bool
+
+	// Only applies to fouls by the offense.
+	LossOfDown bool
+
+	PersonalFoul bool
+}
+
+var FoulRules = map[FoulCode]*FoulRule{
+	FoulCodeChopBlock: {
+		Description: "chop block", Yards: 15, Spot: FoulSpotTypeBasic, Side: FoulSideOffense, PersonalFoul: true,
+	},
+	FoulCodeIllegalSubstitution: {
+		Description: "illegal substitution", Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideEither,
+	},
+	FoulCodeClipping: {
+		Description: "clipping", Yards: 15, Spot: FoulSpotTypeBasic, Side: FoulSideEither, PersonalFoul: true,
+	},
+	FoulCodeIllegalTouchKick: {
+		Description: "illegal touch kick", Yards: 5, Spot: FoulSpotTypeKick, Side: FoulSideSpecialTeams,
+	},
+	FoulCodeDefensiveDelayOfGame: {
+		Description: "defensive delay of game", Yards: 5, Spot: FoulSpotTypeDeadBall, Side: FoulSideDefense,
+	},
+	FoulCodeIllegalTouchPass: {
+		Description: "illegal touch pass", Spot: FoulSpotTypePrevious, Side: FoulSideOffense, LossOfDown: true,
+	},
+	FoulCodeDefensiveHolding: {
+		Description: "defensive holding", Yards: 5, Spot: FoulSpotTypeBasic, Side: FoulSideDefense, AutomaticFirstDown: true,
+	},
+	FoulCodeIllegalUseOfHands: {
+		Description: "illegal use of hands", Yards: 10, DefenseYards: 5, Spot: FoulSpotTypeBasic, Side: FoulSideEither, AutomaticFirstDown: true,
+	},
+	FoulCodeDefensiveOffside: {
+		Description: "defensive offside", Aliases: []string{"defensive offsides"}, Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideDefense,
+	},
+	FoulCodeIllegalWedge: {
+		Description: "illegal wedge", Yards: 15, Spot: FoulSpotTypeKick, Side: FoulSideSpecialTeams,
+	},
+	FoulCodeDefensivePassInterference: {
+		Description: "defensive pass interference", Spot: FoulSpotTypeSpot, Side: FoulSideDefense, AutomaticFirstDown: true,
+	},
+	FoulCodeIneligibleDownfieldKick: {
+		Description: "ineligible downfield kick", Aliases: []string{"ineligible downfield on kick", "illegal downfield on kick"}, Yards: 5, Spot: FoulSpotTypeKick, Side: FoulSideSpecialTeams,
+	},
+	FoulCodeDefensiveTooManyMenOnField: {
+		Description: "defensive too many men on field", Aliases: []string{"defensive 12 on field", "defensive 12 men on field"}, Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideDefense,
+	},
+	FoulCodeIneligibleDownfieldPass: {
+		Description: "ineligible downfield pass", Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideOffense,
+	},
+	FoulCodeDelayOfGame: {
+		Description: "delay of game", Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideOffense,
+	},
+	FoulCodeIntentionalGrounding: {
+		Description: "intentional grounding", Spot: FoulSpotTypeSpot, Side: FoulSideOffense, LossOfDown: true,
+	},
+	FoulCodeDelayOfKickoff: {
+		Description: "delay of kickoff", Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideSpecialTeams,
+	},
+	FoulCodeInvalidFairCatchSignal: {
+		Description: "invalid fair catch signal", Yards: 5, Spot: FoulSpotTypeSpot, Side: FoulSideSpecialTeams,
+	},
+	FoulCodeDisqualification: {
+		Description: "disqualification", Yards: 15, Spot: FoulSpotTypeDeadBall, Side: FoulSideEither, AutomaticFirstDown: true,
+	},
+	FoulCodeKickCatchInterference: {
+		Description: "kick catch interference", Yards: 15, Spot: FoulSpotTypeSpot, Side: FoulSideSpecialTeams,
+	},
+	FoulCodeEncroachment: {
+		Description: "encroachment", Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideDefense,
+	},
+	FoulCodeKickoffOutOfBounds: {
+		Description: "kickoff out of bounds", Spot: FoulSpotTypeKick, Side: FoulSideSpecialTeams,
+	},
+	FoulCodeFacemask: {
+		Description: "facemask", Aliases: []string{"face mask (15 yards)", "face mask"}, Yards: 15, Spot: FoulSpotTypeBasic, Side: FoulSideEither, AutomaticFirstDown: true, PersonalFoul: true,
+	},
+	FoulCodeLeaping: {
+		Description: "leaping", Yards: 15, Spot: FoulSpotTypePrevious, Side: FoulSideDefense, AutomaticFirstDown: true,
+	},
+	FoulCodeFairCatchInterference: {
+		Description: "fair catch interference", Yards: 15, Spot: FoulSpotTypeSpot, Side: FoulSideSpecialTeams,
+	},
+	FoulCodeLeverage: {
+		Description: "leverage", Yards: 15, Spot: FoulSpotTypePrevious, Side: FoulSideDefense, AutomaticFirstDown: true,
+	},
+	FoulCodeFalseStart: {
+		Description: "false start", Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideOffense,
+	},
+	FoulCodeLowBlock: {
+		Description: "low block", Yards: 15, Spot: FoulSpotTypeBasic, Side: FoulSideEither, PersonalFoul: true,
+	},
+	FoulCodeHorseCollar: {
+		Description: "horse collar", Aliases: []string{"horse collar tackle"}, Yards: 15, Spot: FoulSpotTypeBasic, Side: FoulSideEither, AutomaticFirstDown: true, PersonalFoul: true,
+	},
+	FoulCodeNeutralZoneInfraction: {
+		Description: "neutral zone infraction", Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideDefense,
+	},
+	FoulCodeIllegalBat: {
+		Description: "illegal bat", Yards: 10, Spot: FoulSpotTypeSpot, Side: FoulSideEither,
+	},
+	FoulCodeOffensiveHolding: {
+		Description: "offensive holding", Yards: 10, Spot: FoulSpotTypeBasic, Side: FoulSideOffense,
+	},
+	FoulCodeIllegalBlindsideBlock: {
+		Description: "illegal blindside block", Yards: 15, Spot: FoulSpotTypeBasic, Side: FoulSideEither, PersonalFoul: true,
+	},
+	FoulCodeOffensiveOffside: {
+		Description: "offensive offside", Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideOffense,
+	},
+	FoulCodeIllegalBlockAboveTheWaist: {
+		Description: "illegal block above the waist", Aliases: []string{"illegal block in the back"}, Yards: 10, Spot: FoulSpotTypeBasic, Side: FoulSideEither,
+	},
+	FoulCodeOffensivePassInterference: {
+		Description: "offensive pass interference", Yards: 10, Spot: FoulSpotTypePrevious, Side: FoulSideOffense,
+	},
+	FoulCodeIllegalContact: {
+		Description: "illegal contact", Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideDefense, AutomaticFirstDown: true,
+	},
+	FoulCodeOffensiveTooManyMenOnField: {
+		Description: "offensive too many men on field", Aliases: []string{"offensive 12 on field", "offensive 12 men on field"}, Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideOffense,
+	},
+	FoulCodeIllegalCrackback: {
+		Description: "illegal crackback", Yards: 15, Spot: FoulSpotTypeBasic, Side: FoulSideOffense, PersonalFoul: true,
+	},
+	FoulCodeOffsideOnFreeKick: {
+		Description: "offside on free kick", Aliases: []string{"offsides on free kick"}, Yards: 5, Spot: FoulSpotTypeKick, Side: FoulSideSpecialTeams,
+	},
+	FoulCodeIllegalCut: {
+		Description: "illegal cut", Yards: 15, Spot: FoulSpotTypeBasic, Side: FoulSideOffense, PersonalFoul: true,
+	},
+	FoulCodePlayerOutOfBoundsonKick: {
+		Description: "player out of bounds on kick", Yards: 5, Spot: FoulSpotTypeKick, Side: FoulSideSpecialTeams,
+	},
+	FoulCodeIllegalDoubleTeamBlock: {
+		Description: "illegal double team block", Yards: 15, Spot: FoulSpotTypeKick, Side: FoulSideSpecialTeams,
+	},
+	FoulCodeRoughingTheKicker: {
+		Description: "roughing the kicker", Aliases: []string{"roughing the holder"}, Yards: 15, Spot: FoulSpotTypePrevious, Side: FoulSideSpecialTeams, AutomaticFirstDown: true, PersonalFoul: true,
+	},
+	FoulCodeIllegalFormation: {
+		Description: "illegal formation", Aliases: []string{"illegal kickoff formation", "defensive illegal formation", "offensive illegal formation"}, Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideEither,
+	},
+	FoulCodeRoughingThePasser: {
+		Description: "roughing the passer", Yards: 15, Spot: FoulSpotTypeBasic, Side: FoulSideDefense, AutomaticFirstDown: true, PersonalFoul: true,
+	},
+	FoulCodeIllegalForwardHandoff: {
+		Description: "illegal forward handoff", Yards: 5, Spot: FoulSpotTypeSpot, Side: FoulSideOffense, LossOfDown: true,
+	},
+	FoulCodeRunningIntoTheKicker: {
+		Description: "running into the kicker", Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideSpecialTeams,
+	},
+	FoulCodeIllegalForwardPass: {
+		Description: "illegal forward pass", Yards: 5, Spot: FoulSpotTypeSpot, Side: FoulSideOffense, LossOfDown: true,
+	},
+	FoulCodeTaunting: {
+		Description: "taunting", Yards: 15, Spot: FoulSpotTypeDeadBall, Side: FoulSideEither, AutomaticFirstDown: true,
+	},
+	FoulCodeIllegalKick: {
+		Description: "illegal kick/kicking loose ball", Aliases: []string{"illegal kick", "kicking loose ball"}, Yards: 10, Spot: FoulSpotTypeSpot, Side: FoulSideEither,
+	},
+	FoulCodeTripping: {
+		Description: "tripping", Yards: 10, Spot: FoulSpotTypeBasic, Side: FoulSideEither, AutomaticFirstDown: true, PersonalFoul: true,
+	},
+	FoulCodeIllegalMotion: {
+		Description: "illegal motion", Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideOffense,
+	},
+	FoulCodeUnnecessaryRoughness: {
+		Description: "unnecessary roughness", Aliases: []string{"roughing the long snapper"}, Yards: 15, Spot: FoulSpotTypeDeadBall, Side: FoulSideEither, AutomaticFirstDown: true, PersonalFoul: true,
+	},
+	FoulCodeIllegalPeelBack: {
+		Description: "illegal peel back", Yards: 15, Spot: FoulSpotTypeBasic, Side: FoulSideOffense, PersonalFoul: true,
+	},
+	FoulCodeUnsportsmanlikeConduct: {
+		Description: "unsportsmanlike conduct", Yards: 15, Spot: FoulSpotTypeDeadBall, Side: FoulSideEither, AutomaticFirstDown: true,
+	},
+	FoulCodeIllegalShift: {
+		Description: "illegal shift", Yards: 5, Spot: FoulSpotTypePrevious, Side: FoulSideOffense,
+	},
+	FoulCodeUseOfHelmet: {
+		Description: "use of helmet", Aliases: []string{"lowering the head to initiate contact", "lowering the head to make contact"}, Yards: 15, Spot: FoulSpotTypeBasic, Side: FoulSideEither, AutomaticFirstDown: true, PersonalFoul: true,
+	},
+}
+
+// Returns a foul code based on its description text. Multiple descriptions may correspond to a
+// single foul code, so if you need the canonical description for a foul code, use
+// (FoulCode).Description.
+var FoulCodesByDescription = map[string]FoulCode{}
+
+var FoulCodes []FoulCode
+
+func init() {
+	for code, rule := range FoulRules {
+		rule.Code = code
+		for _, desc := range append([]string{rule.Description}, rule.Aliases...) {
+			if _, ok := FoulCodesByDescription[desc]; ok {
+				panic("foul description used by multiple foul codes: " + desc)
+			}
+			FoulCodesByDescription[desc] = code
+		}
+		FoulCodes = append(FoulCodes, code)
+	}
+	sort.Slice(FoulCodes, func(i, j int) bool {
+		return FoulCodes[i] < FoulCodes[j]
+	})
+}
+
+func (c FoulCode) Description() string {
+	if rule, ok := FoulRules[c]; ok {
+		return rule.Description
+	}
+	return ""
+}
+
+// Rule returns the foul's rules, or nil if the foul code is unknown.
+func (c FoulCode) Rule() *FoulRule {
+	return FoulRules[c]
+}
+
+// FoulCodeForDescription returns the foul code for the description text. Case, hyphens, and extra
+// whitespace are ignored.
+func FoulCodeForDescription(description string) (FoulCode, bool) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(description, "-", " "))), " ")
+	code, ok := FoulCodesByDescription[normalized]
+	return code, ok
+}
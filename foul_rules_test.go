@@ -0,0 +1,76 @@
+package gsis
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFoulRules(t *testing.T) {
+	assert.Len(t, FoulCodes, len(FoulRules))
+	for _, code := range FoulCodes {
+		rule := code.Rule()
+		require.NotNil(t, rule, code)
+		assert.Equal(t, code, rule.Code)
+		assert.NotEmpty(t, rule.Description, code)
+		assert.Equal(t, rule.Description, code.Description())
+		assert.NotEmpty(t, rule.Spot, code)
+		assert.NotEmpty(t, rule.Side, code)
+		if rule.Side == FoulSideOffense {
+			assert.False(t, rule.AutomaticFirstDown, code)
+		}
+		if rule.Side == FoulSideDefense {
+			assert.False(t, rule.LossOfDown, code)
+		}
+	}
+
+	assert.Nil(t, FoulCode("XYZ").Rule())
+	assert.Empty(t, FoulCode("XYZ").Description())
+
+	assert.Equal(t, 15, FoulCodeRoughingThePasser.Rule().Yards)
+	assert.True(t, FoulCodeRoughingThePasser.Rule().PersonalFoul)
+	assert.True(t, FoulCodeIntentionalGrounding.Rule().LossOfDown)
+	assert.Equal(t, FoulSpotTypeSpot, FoulCodeDefensivePassInterference.Rule().Spot)
+}
+
+func TestFoulCodeForDescription(t *testing.T) {
+	for description, expected := range map[string]FoulCode{
+		"Offensive Holding":                     FoulCodeOffensiveHolding,
+		"Face Mask (15 Yards)":                  FoulCodeFacemask,
+		"Horse Collar Tackle":                   FoulCodeHorseCollar,
+		"Lowering the Head to Initiate Contact": FoulCodeUseOfHelmet,
+		"Illegal Block in the Back":             FoulCodeIllegalBlockAboveTheWaist,
+		"Ineligible Downfield Kick":             FoulCodeIneligibleDownfieldKick,
+		"Defensive 12 On-field":                 FoulCodeDefensiveTooManyMenOnField,
+		"Illegal Kickoff Formation":             FoulCodeIllegalFormation,
+		"Roughing the Long Snapper":             FoulCodeUnnecessaryRoughness,
+		"  illegal   kick/kicking loose ball ":  FoulCodeIllegalKick,
+	} {
+		code, ok := FoulCodeForDescription(description)
+		assert.True(t, ok, description)
+		assert.Equal(t, expected, code, description)
+	}
+
+	_, ok := FoulCodeForDescription("Illegal Celebration")
+	assert.False(t, ok)
+
+	penalties := ParsePlayDescriptionPenalties("(4:12) 24-M.Lynch left end to SEA 40 for 15 yards (54-B.Wagner). PENALTY on SEA-89-D.Baldwin, Illegal Block in the Back, 10 yards, enforced at SEA 35.")
+	require.Len(t, penalties, 1)
+	assert.Equal(t, FoulCodeIllegalBlockAboveTheWaist, penalties[0].FoulCode)
+}
+
+var penaltyDescriptionFoulRegexp = regexp.MustCompile(`(?i)penalty on [^,]+, ([^,.]+)`)
+
+// Every foul described in the test data should resolve to a foul code.
+func TestFoulCodeForDescription_Games(t *testing.T) {
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		for _, p := range stats.Play {
+			for _, m := range penaltyDescriptionFoulRegexp.FindAllStringSubmatch(p.PlayDescription, -1) {
+				_, ok := FoulCodeForDescription(m[1])
+				assert.True(t, ok, "%q in play %v", m[1], p.PlayID)
+			}
+		}
+	})
+}
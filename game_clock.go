@@ -0,0 +1,179 @@
+package gsis
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	QuarterLength               = 15 * time.Minute
+	RegularSeasonOvertimeLength = 10 * time.Minute
+	PostseasonOvertimeLength    = 15 * time.Minute
+)
+
+// A point in a game: a quarter and the time remaining in it.
+type GameClock struct {
+	// 1 through 4, then 5 and up for overtime periods.
+	Quarter int
+
+	// Time remaining in the quarter.
+	Clock time.Duration
+
+	// Postseason overtime periods are longer, and there can be more than one of them.
+	Postseason bool
+}
+
+func isPostseason(seasonType string) bool {
+	return strings.EqualFold(seasonType, "Post")
+}
+
+// NewGameClock returns a clock for the given quarter. The season type is the one found in
+// CumeStatHeader, e.g. "Reg" or "Post".
+func NewGameClock(quarter int, clock time.Duration, seasonType string) GameClock {
+	return GameClock{
+		Quarter:    quarter,
+		Clock:      clock,
+		Postseason: isPostseason(seasonType),
+	}
+}
+
+func (c GameClock) IsOvertime() bool {
+	return c.Quarter > 4
+}
+
+// Half returns 1 or 2 for regulation quarters, or 0 for overtime.
+func (c GameClock) Half() int {
+	switch {
+	case c.Quarter < 1 || c.IsOvertime():
+		return 0
+	case c.Quarter <= 2:
+		return 1
+	}
+	return 2
+}
+
+func (c GameClock) overtimeLength() time.Duration {
+	if c.Postseason {
+		return PostseasonOvertimeLength
+	}
+	return RegularSeasonOvertimeLength
+}
+
+// QuarterLength returns the length of the clock's quarter or overtime period.
+func (c GameClock) QuarterLength() time.Duration {
+	if c.IsOvertime() {
+		return c.overtimeLength()
+	}
+	return QuarterLength
+}
+
+// Returns true if the quarter is the last one before halftime, the end of regulation, or the end of
+// the game. Postseason overtime periods are paired into halves.
+func (c GameClock) endsHalf() bool {
+	switch {
+	case c.Quarter == 2, c.Quarter == 4:
+		return true
+	case c.IsOvertime():
+		return !c.Postseason || c.Quarter%2 == 0
+	}
+	return false
+}
+
+// AfterTwoMinuteWarning returns true if the clock is within the last two minutes of a half or of
+// an overtime period that ends a half.
+func (c GameClock) AfterTwoMinuteWarning() bool {
+	return c.endsHalf() && c.Clock <= 2*time.Minute
+}
+
+// Elapsed returns the game time elapsed since the opening kickoff.
+func (c GameClock) Elapsed() time.Duration {
+	if c.Quarter < 1 {
+		return 0
+	}
+	var ret time.Duration
+	for q := 1; q < c.Quarter; q++ {
+		ret += GameClock{Quarter: q, Postseason: c.Postseason}.QuarterLength()
+	}
+	if played := c.QuarterLength() - c.Clock; played > 0 {
+		ret += played
+	}
+	return ret
+}
+
+// RemainingInHalf returns the game time left before halftime or the end of regulation. In
+// overtime, it's the time left in the period, or in the pair of periods for the postseason.
+func (c GameClock) RemainingInHalf() time.Duration {
+	if c.endsHalf() || c.Quarter < 1 {
+		return c.Clock
+	}
+	return c.Clock + c.QuarterLength()
+}
+
+// RemainingInRegulation returns the game time left in regulation, or the time left in the
+// period in overtime.
+func (c GameClock) RemainingInRegulation() time.Duration {
+	if c.IsOvertime() || c.Quarter < 1 {
+		return c.Clock
+	}
+	return c.Clock + time.Duration(4-c.Quarter)*QuarterLength
+}
+
+// Compare returns -1 if the clock is earlier in the game than the other, 1 if it's later, and 0 if
+// they're the same.
+func (c GameClock) Compare(other GameClock) int {
+	switch {
+	case c.Quarter < other.Quarter:
+		return -1
+	case c.Quarter > other.Quarter:
+		return 1
+	case c.Clock > other.Clock:
+		return -1
+	case c.Clock < other.Clock:
+		return 1
+	}
+	return 0
+}
+
+func (c GameClock) Before(other GameClock) bool {
+	return c.Compare(other) < 0
+}
+
+func (c GameClock) After(other GameClock) bool {
+	return c.Compare(other) > 0
+}
+
+// Formats time remaining in a quarter like "1:57".
+func formatGameClock(d time.Duration) string {
+	return fmt.Sprintf("%d:%02d", d/time.Minute, (d%time.Minute)/time.Second)
+}
+
+// String returns the clock in a form like "Q2 1:57" or "OT 8:12". Additional postseason overtime
+// periods are numbered, e.g. "OT2 15:00".
+func (c GameClock) String() string {
+	clock := formatGameClock(c.Clock)
+	switch {
+	case c.Quarter == 5:
+		return "OT " + clock
+	case c.IsOvertime():
+		return fmt.Sprintf("OT%d %v", c.Quarter-4, clock)
+	}
+	return fmt.Sprintf("Q%d %v", c.Quarter, clock)
+}
+
+// StartClock returns the game clock at the snap. It returns false if the play has no clock time.
+func (p *StatFilePlay) StartClock(seasonType string) (GameClock, bool) {
+	if p.ClockTime.IsNil() {
+		return GameClock{}, false
+	}
+	return NewGameClock(int(p.Quarter), p.ClockTime.Duration(), seasonType), true
+}
+
+// EndClock returns the game clock at the end of the play. It returns false if the play has no end
+// clock time, which is common.
+func (p *StatFilePlay) EndClock(seasonType string) (GameClock, bool) {
+	if p.EndClockTime.IsNil() {
+		return GameClock{}, false
+	}
+	return NewGameClock(int(p.Quarter), p.EndClockTime.Duration(), seasonType), true
+}
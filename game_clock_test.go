@@ -0,0 +1,144 @@
+package gsis
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameClock(t *testing.T) {
+	for _, tc := range []struct {
+		Clock                 GameClock
+		String                string
+		Elapsed               time.Duration
+		RemainingInHalf       time.Duration
+		RemainingInRegulation time.Duration
+		Half                  int
+		AfterTwoMinuteWarning bool
+	}{
+		{
+			Clock:                 NewGameClock(1, 15*time.Minute, "Reg"),
+			String:                "Q1 15:00",
+			RemainingInHalf:       30 * time.Minute,
+			RemainingInRegulation: 60 * time.Minute,
+			Half:                  1,
+		},
+		{
+			Clock:                 NewGameClock(2, 2*time.Minute, "Reg"),
+			String:                "Q2 2:00",
+			Elapsed:               28 * time.Minute,
+			RemainingInHalf:       2 * time.Minute,
+			RemainingInRegulation: 32 * time.Minute,
+			Half:                  1,
+			AfterTwoMinuteWarning: true,
+		},
+		{
+			Clock:                 NewGameClock(3, 90*time.Second, "Reg"),
+			String:                "Q3 1:30",
+			Elapsed:               43*time.Minute + 30*time.Second,
+			RemainingInHalf:       16*time.Minute + 30*time.Second,
+			RemainingInRegulation: 16*time.Minute + 30*time.Second,
+			Half:                  2,
+		},
+		{
+			Clock:                 NewGameClock(5, 3*time.Minute, "Reg"),
+			String:                "OT 3:00",
+			Elapsed:               67 * time.Minute,
+			RemainingInHalf:       3 * time.Minute,
+			RemainingInRegulation: 3 * time.Minute,
+			AfterTwoMinuteWarning: false,
+		},
+		{
+			Clock:                 NewGameClock(5, 90*time.Second, "Reg"),
+			String:                "OT 1:30",
+			Elapsed:               68*time.Minute + 30*time.Second,
+			RemainingInHalf:       90 * time.Second,
+			RemainingInRegulation: 90 * time.Second,
+			AfterTwoMinuteWarning: true,
+		},
+		{
+			// Postseason overtime periods are longer and come in pairs, like quarters.
+			Clock:                 NewGameClock(5, 90*time.Second, "POST"),
+			String:                "OT 1:30",
+			Elapsed:               73*time.Minute + 30*time.Second,
+			RemainingInHalf:       16*time.Minute + 30*time.Second,
+			RemainingInRegulation: 90 * time.Second,
+		},
+		{
+			Clock:                 NewGameClock(6, 90*time.Second, "Post"),
+			String:                "OT2 1:30",
+			Elapsed:               88*time.Minute + 30*time.Second,
+			RemainingInHalf:       90 * time.Second,
+			RemainingInRegulation: 90 * time.Second,
+			AfterTwoMinuteWarning: true,
+		},
+	} {
+		t.Run(tc.String, func(t *testing.T) {
+			assert.Equal(t, tc.String, tc.Clock.String())
+			assert.Equal(t, tc.Elapsed, tc.Clock.Elapsed())
+			assert.Equal(t, tc.RemainingInHalf, tc.Clock.RemainingInHalf())
+			assert.Equal(t, tc.RemainingInRegulation, tc.Clock.RemainingInRegulation())
+			assert.Equal(t, tc.Half, tc.Clock.Half())
+			assert.Equal(t, tc.AfterTwoMinuteWarning, tc.Clock.AfterTwoMinuteWarning())
+		})
+	}
+
+	a := NewGameClock(2, 30*time.Second, "Reg")
+	b := NewGameClock(3, 15*time.Minute, "Reg")
+	assert.True(t, a.Before(b))
+	assert.True(t, b.After(a))
+	assert.Equal(t, 0, a.Compare(a))
+	assert.True(t, NewGameClock(2, 31*time.Second, "Reg").Before(a))
+	assert.Equal(t, GameClock{}.Elapsed(), time.Duration(0))
+}
+
+func TestStatFilePlay_Clock(t *testing.T) {
+	buf, err := ioutil.ReadFile("testdata/games/2020010400/GSISGameStats.xml")
+	require.NoError(t, err)
+	var stats StatFile
+	require.NoError(t, xml.Unmarshal(buf, &stats))
+	seasonType := stats.CumeStatHeader.SeasonType
+
+	var fieldGoal *StatFilePlay
+	for _, p := range stats.Play {
+		if p.PlayID == 4844 {
+			fieldGoal = p
+		}
+	}
+	require.NotNil(t, fieldGoal)
+
+	start, ok := fieldGoal.StartClock(seasonType)
+	require.True(t, ok)
+	assert.Equal(t, "OT 3:23", start.String())
+	assert.Equal(t, 71*time.Minute+37*time.Second, start.Elapsed())
+
+	end, ok := fieldGoal.EndClock(seasonType)
+	require.True(t, ok)
+	assert.Equal(t, 71*time.Minute+40*time.Second, end.Elapsed())
+
+	_, ok = (&StatFilePlay{}).EndClock(seasonType)
+	assert.False(t, ok)
+}
+
+func TestStatFilePlay_Clock_Games(t *testing.T) {
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		require.NotNil(t, stats.CumeStatHeader)
+		seasonType := stats.CumeStatHeader.SeasonType
+
+		// Plays are in chronological order. Timeouts sometimes have the wrong time.
+		var previous GameClock
+		for _, p := range stats.ActualPlays() {
+			start, ok := p.StartClock(seasonType)
+			if !ok || p.PlayType == PlayTypeTimeout {
+				continue
+			}
+			assert.False(t, start.Before(previous), "%v is before %v: %v", start, previous, p.PlayDescription)
+			assert.True(t, start.Elapsed() >= previous.Elapsed())
+			previous = start
+		}
+	})
+}
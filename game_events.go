@@ -0,0 +1,255 @@
+package gsis
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type GameEventType string
+
+const (
+	// A timeout charged to a team, including those charged for failed challenges and injuries late
+	// in a half.
+	GameEventTypeTimeout GameEventType = "TIMEOUT"
+
+	// A stoppage for an injury that isn't charged to a team.
+	GameEventTypeInjuryTimeout GameEventType = "INJURY_TIMEOUT"
+
+	// Any other stoppage that isn't charged to a team, such as a TV or officials' timeout.
+	GameEventTypeOfficialTimeout GameEventType = "OFFICIAL_TIMEOUT"
+
+	GameEventTypeTwoMinuteWarning GameEventType = "TWO_MINUTE_WARNING"
+	GameEventTypeEndOfQuarter     GameEventType = "END_OF_QUARTER"
+	GameEventTypeEndOfGame        GameEventType = "END_OF_GAME"
+
+	// A delay such as for weather, and the resumption of play afterwards.
+	GameEventTypeSuspended GameEventType = "SUSPENDED"
+	GameEventTypeResumed   GameEventType = "RESUMED"
+)
+
+// A clock stoppage or other event between plays.
+type GameEvent struct {
+	Type GameEventType
+
+	// The play the event was recorded with. For timeouts charged for failed challenges, this is the
+	// challenged play.
+	Play *StatFilePlay
+
+	// The game clock when the event happened, if it's known.
+	Clock      GameClock
+	ClockKnown bool
+
+	// For timeouts, the team charged and which of the team's timeouts in the half it was, if known.
+	ClubCode      string
+	TimeoutNumber int
+
+	// True if a team's timeout was charged because of an injury or a failed challenge.
+	Injury    bool
+	Challenge bool
+
+	// The number of timeouts each team has remaining after the event.
+	HomeTimeouts    int
+	VisitorTimeouts int
+}
+
+var (
+	gameEventTimeoutRegexp      = regexp.MustCompile(`^Timeout #(\d) by ([A-Z]+)(?: at (\d*:\d\d))?`)
+	gameEventUnchargedRegexp    = regexp.MustCompile(`^Timeout at (\d*:\d\d)`)
+	gameEventInjuryRegexp       = regexp.MustCompile(`(?i)injur|medical`)
+	gameEventTwoMinuteRegexp    = regexp.MustCompile(`^Two-Minute Warning`)
+	gameEventEndOfQuarterRegexp = regexp.MustCompile(`^END QUARTER (\d+)`)
+	gameEventInjuryStopRegexp   = regexp.MustCompile(`(?i)^Time for Injury|injury timeout|Injured on the play`)
+	gameEventSuspendedRegexp    = regexp.MustCompile(`^The game has been suspended`)
+	gameEventResumedRegexp      = regexp.MustCompile(`^The game has resumed`)
+)
+
+// Parses a clock such as "04:52" or ":21".
+func parseGameEventClock(s string) (time.Duration, bool) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return 0, false
+	}
+	minutes := 0
+	if parts[0] != "" {
+		var err error
+		if minutes, err = strconv.Atoi(parts[0]); err != nil {
+			return 0, false
+		}
+	}
+	seconds, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, true
+}
+
+// GameEvents returns the game's timeouts, two-minute warnings, injury stoppages, and ends of
+// quarters, in play order. Deleted plays are ignored.
+//
+// Each team's remaining timeouts are tracked across halves and overtime periods. Timeouts are
+// attributed to teams using the description, falling back to the play's timeout stat.
+func (f *StatFile) GameEvents() []*GameEvent {
+	var homeClubCode, visitorClubCode, seasonType string
+	if f.CumeStatHeader != nil {
+		homeClubCode = f.CumeStatHeader.HomeClubCode
+		visitorClubCode = f.CumeStatHeader.VisitorClubCode
+		seasonType = f.CumeStatHeader.SeasonType
+	}
+	postseason := isPostseason(seasonType)
+	isTeam := func(clubCode, team string) bool {
+		return team != "" && CommonTeamAbbreviation(clubCode) == CommonTeamAbbreviation(team)
+	}
+
+	timeoutStats := map[StringInt]string{}
+	for _, stat := range f.PlayStat {
+		if stat.StatID == StatIDTimeout {
+			timeoutStats[stat.PlayID] = stat.ClubCode
+		}
+	}
+
+	var ret []*GameEvent
+	quarter := 0
+	allotment := 0
+	homeTimeouts, visitorTimeouts := 0, 0
+
+	add := func(e *GameEvent) {
+		if e.Type == GameEventTypeTimeout {
+			remaining := -1
+			if e.TimeoutNumber > 0 {
+				remaining = allotment - e.TimeoutNumber
+			}
+			switch {
+			case isTeam(e.ClubCode, homeClubCode):
+				if remaining < 0 {
+					remaining = homeTimeouts - 1
+				}
+				if remaining < 0 {
+					remaining = 0
+				}
+				homeTimeouts = remaining
+			case isTeam(e.ClubCode, visitorClubCode):
+				if remaining < 0 {
+					remaining = visitorTimeouts - 1
+				}
+				if remaining < 0 {
+					remaining = 0
+				}
+				visitorTimeouts = remaining
+			}
+		}
+		e.HomeTimeouts = homeTimeouts
+		e.VisitorTimeouts = visitorTimeouts
+		ret = append(ret, e)
+	}
+
+	for _, p := range f.Play {
+		if p.PlayDeleted != 0 {
+			continue
+		}
+
+		for quarter < int(p.Quarter) {
+			quarter++
+			if timeouts := timeoutsForQuarter(quarter, postseason); timeouts > 0 {
+				allotment = timeouts
+				homeTimeouts = timeouts
+				visitorTimeouts = timeouts
+			}
+		}
+
+		e := &GameEvent{
+			Play: p,
+		}
+		e.Clock, e.ClockKnown = p.StartClock(seasonType)
+		description := strings.TrimSpace(p.PlayDescription)
+
+		switch p.PlayType {
+		case PlayTypeTimeout:
+			if m := gameEventTimeoutRegexp.FindStringSubmatch(description); m != nil {
+				e.Type = GameEventTypeTimeout
+				e.TimeoutNumber, _ = strconv.Atoi(m[1])
+				e.ClubCode = m[2]
+				e.Injury = gameEventInjuryRegexp.MatchString(description)
+			} else if gameEventTwoMinuteRegexp.MatchString(description) {
+				e.Type = GameEventTypeTwoMinuteWarning
+				e.Clock = NewGameClock(int(p.Quarter), 2*time.Minute, seasonType)
+				e.ClockKnown = true
+			} else if clubCode, ok := timeoutStats[p.PlayID]; ok {
+				e.Type = GameEventTypeTimeout
+				e.ClubCode = clubCode
+				e.Injury = gameEventInjuryRegexp.MatchString(description)
+			} else if gameEventInjuryRegexp.MatchString(description) {
+				e.Type = GameEventTypeInjuryTimeout
+			} else {
+				e.Type = GameEventTypeOfficialTimeout
+			}
+			if m := gameEventUnchargedRegexp.FindStringSubmatch(description); m != nil && !e.ClockKnown {
+				if clock, ok := parseGameEventClock(m[1]); ok {
+					e.Clock, e.ClockKnown = NewGameClock(int(p.Quarter), clock, seasonType), true
+				}
+			}
+			add(e)
+		case PlayTypeEndQuarter:
+			e.Type = GameEventTypeEndOfQuarter
+			e.Clock, e.ClockKnown = NewGameClock(int(p.Quarter), 0, seasonType), true
+			if m := gameEventEndOfQuarterRegexp.FindStringSubmatch(description); m != nil {
+				if n, err := strconv.Atoi(m[1]); err == nil {
+					e.Clock.Quarter = n
+				}
+			}
+			add(e)
+		case PlayTypeEndGame:
+			e.Type = GameEventTypeEndOfGame
+			add(e)
+		case PlayTypeComment:
+			switch {
+			case gameEventSuspendedRegexp.MatchString(description):
+				e.Type = GameEventTypeSuspended
+			case gameEventResumedRegexp.MatchString(description):
+				e.Type = GameEventTypeResumed
+			case gameEventInjuryStopRegexp.MatchString(description):
+				// Other comments, such as injury updates, don't stop the clock.
+				e.Type = GameEventTypeInjuryTimeout
+			default:
+				continue
+			}
+			add(e)
+		default:
+			// Timeouts charged for failed challenges are recorded with the challenged play.
+			for _, review := range ParsePlayDescriptionReviews(p.PlayDescription) {
+				if !review.TimeoutCharged {
+					continue
+				}
+				e := &GameEvent{
+					Type:          GameEventTypeTimeout,
+					Play:          p,
+					ClubCode:      review.ClubCode,
+					TimeoutNumber: review.TimeoutNumber,
+					Challenge:     true,
+				}
+				if e.ClubCode == "" {
+					e.ClubCode = timeoutStats[p.PlayID]
+				}
+				if clock, ok := parseGameEventClock(review.TimeoutClock); ok {
+					e.Clock, e.ClockKnown = NewGameClock(int(p.Quarter), clock, seasonType), true
+				} else {
+					e.Clock, e.ClockKnown = p.EndClock(seasonType)
+				}
+				add(e)
+			}
+		}
+	}
+	return ret
+}
+
+// Timeouts returns the timeouts charged to a team, in order.
+func (f *StatFile) Timeouts(clubCode string) []*GameEvent {
+	var ret []*GameEvent
+	for _, e := range f.GameEvents() {
+		if e.Type == GameEventTypeTimeout && CommonTeamAbbreviation(e.ClubCode) == CommonTeamAbbreviation(clubCode) {
+			ret = append(ret, e)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,118 @@
+package gsis
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatFile_GameEvents(t *testing.T) {
+	buf, err := ioutil.ReadFile("testdata/games/2020010400/GSISGameStats.xml")
+	require.NoError(t, err)
+	var stats StatFile
+	require.NoError(t, xml.Unmarshal(buf, &stats))
+
+	events := stats.GameEvents()
+	counts := map[GameEventType]int{}
+	byPlay := map[StringInt]*GameEvent{}
+	for _, e := range events {
+		counts[e.Type]++
+		byPlay[e.Play.PlayID] = e
+	}
+	assert.Equal(t, 4, counts[GameEventTypeEndOfQuarter])
+	assert.Equal(t, 1, counts[GameEventTypeEndOfGame])
+	assert.Equal(t, 2, counts[GameEventTypeTwoMinuteWarning])
+
+	// A timeout charged for a failed challenge.
+	challenge := byPlay[384]
+	require.NotNil(t, challenge)
+	assert.Equal(t, GameEventTypeTimeout, challenge.Type)
+	assert.True(t, challenge.Challenge)
+	assert.Equal(t, "HST", challenge.ClubCode)
+	assert.Equal(t, 1, challenge.TimeoutNumber)
+	assert.Equal(t, 2, challenge.HomeTimeouts)
+	assert.Equal(t, 3, challenge.VisitorTimeouts)
+
+	timeout := byPlay[625]
+	require.NotNil(t, timeout)
+	assert.Equal(t, GameEventTypeTimeout, timeout.Type)
+	assert.Equal(t, "BUF", timeout.ClubCode)
+	assert.Equal(t, 1, timeout.TimeoutNumber)
+	assert.True(t, timeout.ClockKnown)
+	assert.Equal(t, NewGameClock(1, 4*time.Minute+52*time.Second, stats.CumeStatHeader.SeasonType), timeout.Clock)
+	assert.Equal(t, 2, timeout.VisitorTimeouts)
+
+	warning := byPlay[3553]
+	require.NotNil(t, warning)
+	assert.Equal(t, GameEventTypeTwoMinuteWarning, warning.Type)
+	assert.Equal(t, NewGameClock(4, 2*time.Minute, stats.CumeStatHeader.SeasonType), warning.Clock)
+
+	// Buffalo used all of its timeouts in the fourth quarter, then got three more for overtime.
+	assert.Equal(t, 0, byPlay[3730].VisitorTimeouts)
+	assert.Equal(t, 0, byPlay[4175].VisitorTimeouts)
+	assert.Equal(t, 2, byPlay[4416].VisitorTimeouts)
+
+	endOfQuarter := byPlay[4175]
+	assert.Equal(t, GameEventTypeEndOfQuarter, endOfQuarter.Type)
+	assert.Equal(t, 4, endOfQuarter.Clock.Quarter)
+	assert.Equal(t, GameEventTypeEndOfGame, byPlay[4863].Type)
+
+	buffalo := stats.Timeouts("BUF")
+	require.Len(t, buffalo, 7)
+	for _, e := range buffalo {
+		assert.Equal(t, "BUF", e.ClubCode)
+	}
+	assert.Len(t, stats.Timeouts("HST"), 5)
+}
+
+func TestStatFile_GameEvents_Comments(t *testing.T) {
+	stats := StatFile{
+		CumeStatHeader: &StatFileCumeStatHeader{
+			HomeClubCode:    "KC",
+			VisitorClubCode: "DEN",
+			SeasonType:      "Reg",
+		},
+		Play: []*StatFilePlay{
+			{PlayID: 1, Quarter: 2, PlayType: PlayTypeComment, PlayDescription: "The game has been suspended due to lightning in the area."},
+			{PlayID: 2, Quarter: 2, PlayType: PlayTypeComment, PlayDescription: "The game has resumed."},
+			{PlayID: 3, Quarter: 2, PlayType: PlayTypeComment, PlayDescription: "Time for Injury to Down Judge #68 T.Stephan"},
+			{PlayID: 4, Quarter: 2, PlayType: PlayTypeComment, PlayDescription: "Injury update: T.Kelce has a knee injury and is questionable to return."},
+			{PlayID: 5, Quarter: 2, PlayType: PlayTypeComment, PlayDescription: "Weather is clear."},
+			{PlayID: 6, Quarter: 2, PlayType: PlayTypeTimeout, PlayDescription: "Timeout #1 by DEN at 01:12."},
+		},
+	}
+	events := stats.GameEvents()
+	require.Len(t, events, 4)
+	assert.Equal(t, GameEventTypeSuspended, events[0].Type)
+	assert.Equal(t, GameEventTypeResumed, events[1].Type)
+	assert.Equal(t, GameEventTypeInjuryTimeout, events[2].Type)
+	assert.EqualValues(t, 3, events[2].Play.PlayID)
+	assert.Equal(t, GameEventTypeTimeout, events[3].Type)
+	assert.Equal(t, "DEN", events[3].ClubCode)
+	assert.Equal(t, 3, events[3].HomeTimeouts)
+	assert.Equal(t, 2, events[3].VisitorTimeouts)
+}
+
+func TestStatFile_GameEvents_Games(t *testing.T) {
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		events := map[StringInt]bool{}
+		for _, e := range stats.GameEvents() {
+			events[e.Play.PlayID] = true
+			for _, timeouts := range []int{e.HomeTimeouts, e.VisitorTimeouts} {
+				assert.True(t, timeouts >= 0 && timeouts <= 3)
+			}
+			if e.Type == GameEventTypeTimeout {
+				assert.NotEmpty(t, e.ClubCode, e.Play.PlayDescription)
+			}
+		}
+		for _, p := range stats.Play {
+			if p.PlayDeleted == 0 && p.PlayType == PlayTypeTimeout {
+				assert.True(t, events[p.PlayID], p.PlayDescription)
+			}
+		}
+	})
+}
@@ -37,3 +37,52 @@ func TeamClubCode(abbr string) string {
 	}
 	return abbr
 }
+
+// Club codes by the team names used in play descriptions, such as "Los Angeles Rams challenged the
+// pass completion ruling".
+var teamNameClubCodes = map[string]string{
+	"Arizona":              "ARZ",
+	"Atlanta":              "ATL",
+	"Baltimore":            "BLT",
+	"Buffalo":              "BUF",
+	"Carolina":             "CAR",
+	"Chicago":              "CHI",
+	"Cincinnati":           "CIN",
+	"Cleveland":            "CLV",
+	"Dallas":               "DAL",
+	"Denver":               "DEN",
+	"Detroit":              "DET",
+	"Green Bay":            "GB",
+	"Houston":              "HST",
+	"Indianapolis":         "IND",
+	"Jacksonville":         "JAX",
+	"Kansas City":          "KC",
+	"Las Vegas":            "LV",
+	"Los Angeles Chargers": "LAC",
+	"Los Angeles Rams":     "LA",
+	"Miami":                "MIA",
+	"Minnesota":            "MIN",
+	"New England":          "NE",
+	"New Orleans":          "NO",
+	"New York Giants":      "NYG",
+	"New York Jets":        "NYJ",
+	"Oakland":              "OAK",
+	"Philadelphia":         "PHI",
+	"Pittsburgh":           "PIT",
+	"San Diego":            "SD",
+	"San Francisco":        "SF",
+	"Seattle":              "SEA",
+	"St. Louis":            "SL",
+	"Tampa Bay":            "TB",
+	"Tennessee":            "TEN",
+	"Washington":           "WAS",
+	"AFC":                  "AFC",
+	"NFC":                  "NFC",
+}
+
+// TeamNameClubCode returns the GSIS club code for a team name as it appears in play descriptions,
+// e.g. "Green Bay" or "New York Jets".
+func TeamNameClubCode(name string) (string, bool) {
+	code, ok := teamNameClubCodes[name]
+	return code, ok
+}
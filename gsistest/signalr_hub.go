@@ -0,0 +1,396 @@
+// Package gsistest provides utilities for testing code that uses GSIS.
+package gsistest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// SignalRHandler handles a hub method invocation. The result is serialized as the invocation's
+// response. If an error is returned, it is sent to the client as the invocation's error message.
+type SignalRHandler func(args []json.RawMessage) (interface{}, error)
+
+// An invocation received by a SignalRHub.
+type SignalRInvocation struct {
+	ConnectionToken string
+	Hub             string
+	Method          string
+	Args            []json.RawMessage
+}
+
+// SignalRHub is an in-process SignalR server speaking the 1.5 protocol over websockets. It
+// supports the negotiate, connect, reconnect, start, and abort endpoints and sends keep-alives.
+//
+// Use its URL as the URL of a gsis.SignalRClient.
+type SignalRHub struct {
+	// The URL of the hub, such as "http://127.0.0.1:1234/signalr".
+	URL string
+
+	server *httptest.Server
+
+	mutex           sync.Mutex
+	handlers        map[string]SignalRHandler
+	invocations     []SignalRInvocation
+	invocationAdded chan struct{}
+	connections     map[*signalRHubConnection]struct{}
+	negotiations    int
+	nextToken       int
+	nextMessageId   int
+	delay           time.Duration
+	keepAlive       time.Duration
+	failNegotiate   int
+}
+
+type signalRHubConnection struct {
+	token string
+	conn  *websocket.Conn
+
+	// Serializes writes to the websocket.
+	writeMutex sync.Mutex
+	closed     chan struct{}
+	closeOnce  sync.Once
+}
+
+func (c *signalRHubConnection) write(v interface{}) error {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	return c.conn.WriteMessage(websocket.TextMessage, buf)
+}
+
+func (c *signalRHubConnection) close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.conn.Close()
+	})
+}
+
+// NewSignalRHub starts a hub. Callers should call Close when finished.
+func NewSignalRHub() *SignalRHub {
+	h := &SignalRHub{
+		handlers:        make(map[string]SignalRHandler),
+		invocationAdded: make(chan struct{}),
+		connections:     make(map[*signalRHubConnection]struct{}),
+		keepAlive:       10 * time.Second,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/signalr/negotiate", h.negotiate)
+	mux.HandleFunc("/signalr/connect", h.connect)
+	mux.HandleFunc("/signalr/reconnect", h.connect)
+	mux.HandleFunc("/signalr/start", h.start)
+	mux.HandleFunc("/signalr/abort", h.abort)
+	h.server = httptest.NewServer(mux)
+	h.URL = h.server.URL + "/signalr"
+	return h
+}
+
+// Close disconnects all clients and shuts down the hub.
+func (h *SignalRHub) Close() {
+	h.Disconnect()
+	h.server.Close()
+}
+
+func handlerKey(hub, method string) string {
+	return strings.ToLower(hub) + "." + strings.ToLower(method)
+}
+
+// Handle registers a handler for a hub method. Like SignalR, hub and method names are
+// case-insensitive. Invocations of methods without handlers receive an error.
+func (h *SignalRHub) Handle(hub, method string, handler SignalRHandler) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.handlers[handlerKey(hub, method)] = handler
+}
+
+// SetDelay delays every invocation response by the given duration.
+func (h *SignalRHub) SetDelay(d time.Duration) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.delay = d
+}
+
+// SetKeepAlive sets the interval at which keep-alives are sent to newly connected clients. By
+// default this is 10 seconds. If it's zero or negative, no keep-alives are sent.
+func (h *SignalRHub) SetKeepAlive(d time.Duration) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.keepAlive = d
+}
+
+// FailNegotiations causes the next n negotiate requests to fail with an internal server error.
+func (h *SignalRHub) FailNegotiations(n int) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.failNegotiate = n
+}
+
+// Push invokes a method on every connected client. It returns the number of clients the message
+// was sent to.
+func (h *SignalRHub) Push(hub, method string, args ...interface{}) int {
+	if args == nil {
+		args = []interface{}{}
+	}
+
+	h.mutex.Lock()
+	h.nextMessageId++
+	msg := map[string]interface{}{
+		"C": fmt.Sprintf("d-%d", h.nextMessageId),
+		"M": []interface{}{
+			map[string]interface{}{
+				"H": hub,
+				"M": method,
+				"A": args,
+			},
+		},
+	}
+	connections := h.connectionList()
+	h.mutex.Unlock()
+
+	n := 0
+	for _, c := range connections {
+		if err := c.write(msg); err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Disconnect abruptly closes every client's websocket without a close handshake.
+func (h *SignalRHub) Disconnect() {
+	h.mutex.Lock()
+	connections := h.connectionList()
+	h.mutex.Unlock()
+	for _, c := range connections {
+		c.close()
+	}
+}
+
+// Connections returns the number of connected clients.
+func (h *SignalRHub) Connections() int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return len(h.connections)
+}
+
+// Negotiations returns the number of successful negotiate requests.
+func (h *SignalRHub) Negotiations() int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.negotiations
+}
+
+// Invocations returns every invocation received so far, in order.
+func (h *SignalRHub) Invocations() []SignalRInvocation {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return append([]SignalRInvocation(nil), h.invocations...)
+}
+
+// InvocationsOf returns the invocations of a hub method received so far, in order.
+func (h *SignalRHub) InvocationsOf(hub, method string) []SignalRInvocation {
+	var ret []SignalRInvocation
+	for _, invocation := range h.Invocations() {
+		if handlerKey(invocation.Hub, invocation.Method) == handlerKey(hub, method) {
+			ret = append(ret, invocation)
+		}
+	}
+	return ret
+}
+
+// WaitForInvocations blocks until at least n invocations of a hub method have been received or
+// the timeout elapses, and returns the invocations received.
+func (h *SignalRHub) WaitForInvocations(hub, method string, n int, timeout time.Duration) []SignalRInvocation {
+	deadline := time.After(timeout)
+	for {
+		h.mutex.Lock()
+		added := h.invocationAdded
+		h.mutex.Unlock()
+
+		if invocations := h.InvocationsOf(hub, method); len(invocations) >= n {
+			return invocations
+		}
+
+		select {
+		case <-added:
+		case <-deadline:
+			return h.InvocationsOf(hub, method)
+		}
+	}
+}
+
+// Must be called with the mutex held.
+func (h *SignalRHub) connectionList() []*signalRHubConnection {
+	ret := make([]*signalRHubConnection, 0, len(h.connections))
+	for c := range h.connections {
+		ret = append(ret, c)
+	}
+	return ret
+}
+
+func (h *SignalRHub) negotiate(w http.ResponseWriter, r *http.Request) {
+	h.mutex.Lock()
+	if h.failNegotiate > 0 {
+		h.failNegotiate--
+		h.mutex.Unlock()
+		http.Error(w, "negotiate failed", http.StatusInternalServerError)
+		return
+	}
+	h.negotiations++
+	h.nextToken++
+	token := strconv.Itoa(h.nextToken)
+	keepAlive := h.keepAlive
+	h.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"Url":                     strings.TrimPrefix(h.URL, h.server.URL),
+		"ConnectionToken":         token,
+		"ConnectionId":            token,
+		"KeepAliveTimeout":        keepAlive.Seconds() * 2,
+		"DisconnectTimeout":       30.0,
+		"ConnectionTimeout":       110.0,
+		"TryWebSockets":           true,
+		"ProtocolVersion":         "1.5",
+		"TransportConnectTimeout": 5.0,
+		"LongPollDelay":           0.0,
+	})
+}
+
+func (h *SignalRHub) connect(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	token := query.Get("connectionToken")
+	if token == "" {
+		http.Error(w, "missing connection token", http.StatusBadRequest)
+		return
+	} else if query.Get("transport") != "webSockets" {
+		http.Error(w, "unsupported transport", http.StatusBadRequest)
+		return
+	}
+
+	conn, err := (&websocket.Upgrader{EnableCompression: true}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	c := &signalRHubConnection{
+		token:  token,
+		conn:   conn,
+		closed: make(chan struct{}),
+	}
+
+	h.mutex.Lock()
+	h.connections[c] = struct{}{}
+	keepAlive := h.keepAlive
+	h.mutex.Unlock()
+
+	defer func() {
+		h.mutex.Lock()
+		delete(h.connections, c)
+		h.mutex.Unlock()
+		c.close()
+	}()
+
+	// the init message
+	if err := c.write(map[string]interface{}{"C": "s-0", "S": 1, "M": []interface{}{}}); err != nil {
+		return
+	}
+
+	if keepAlive > 0 {
+		go func() {
+			ticker := time.NewTicker(keepAlive)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					if err := c.write(struct{}{}); err != nil {
+						return
+					}
+				case <-c.closed:
+					return
+				}
+			}
+		}()
+	}
+
+	for {
+		_, p, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg struct {
+			H string
+			M string
+			A []json.RawMessage
+			I json.Number
+		}
+		if err := json.Unmarshal(p, &msg); err != nil {
+			continue
+		}
+		// Invocations are recorded in the order they're received, but handled concurrently.
+		handler, delay := h.record(c, msg.H, msg.M, msg.A)
+		go h.respond(c, handler, delay, msg.M, msg.A, msg.I.String())
+	}
+}
+
+func (h *SignalRHub) record(c *signalRHubConnection, hub, method string, args []json.RawMessage) (SignalRHandler, time.Duration) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.invocations = append(h.invocations, SignalRInvocation{
+		ConnectionToken: c.token,
+		Hub:             hub,
+		Method:          method,
+		Args:            args,
+	})
+	close(h.invocationAdded)
+	h.invocationAdded = make(chan struct{})
+	return h.handlers[handlerKey(hub, method)], h.delay
+}
+
+func (h *SignalRHub) respond(c *signalRHubConnection, handler SignalRHandler, delay time.Duration, method string, args []json.RawMessage, id string) {
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-c.closed:
+			return
+		}
+	}
+
+	resp := map[string]interface{}{"I": id}
+	if handler == nil {
+		resp["E"] = fmt.Sprintf("'%v' method could not be resolved.", method)
+	} else if result, err := handler(args); err != nil {
+		resp["E"] = err.Error()
+	} else {
+		resp["R"] = result
+	}
+	c.write(resp)
+}
+
+func (h *SignalRHub) start(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"Response":"started"}`))
+}
+
+func (h *SignalRHub) abort(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("connectionToken")
+	h.mutex.Lock()
+	connections := h.connectionList()
+	h.mutex.Unlock()
+	for _, c := range connections {
+		if c.token == token {
+			c.close()
+		}
+	}
+}
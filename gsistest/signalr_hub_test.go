@@ -0,0 +1,218 @@
+package gsistest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sportsball-ai/gsis"
+	"github.com/sportsball-ai/gsis/gsistest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newClient(hub *gsistest.SignalRHub) *gsis.SignalRClient {
+	return &gsis.SignalRClient{
+		URL:            hub.URL,
+		ConnectionData: `[{"name":"gamestatshub"},{"name":"schedulehub"}]`,
+	}
+}
+
+func TestSignalRHub(t *testing.T) {
+	hub := gsistest.NewSignalRHub()
+	defer hub.Close()
+	hub.SetKeepAlive(10 * time.Millisecond)
+	hub.Handle("schedulehub", "RegisterForSchedule", func(args []json.RawMessage) (interface{}, error) {
+		return []map[string]interface{}{{"GameKey": "58155"}}, nil
+	})
+
+	c := newClient(hub)
+	defer c.Close()
+
+	conn, err := c.Connection()
+	require.NoError(t, err)
+	messages, unsubscribe := conn.SubscribeHubMessages("schedulehub", 10)
+	defer unsubscribe()
+
+	resp, err := conn.Invoke(context.Background(), "ScheduleHub", "registerForSchedule", "2019", "REG", 17)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"GameKey":"58155"}]`, string(resp))
+
+	_, err = conn.Invoke(context.Background(), "gamestatshub", "Unknown")
+	assert.Error(t, err)
+
+	invocations := hub.InvocationsOf("schedulehub", "RegisterForSchedule")
+	require.Len(t, invocations, 1)
+	assert.Equal(t, `["2019","REG",17]`, mustMarshal(t, invocations[0].Args))
+
+	// Keep-alives are ignored by the client.
+	time.Sleep(50 * time.Millisecond)
+	assert.False(t, conn.IsClosed())
+
+	assert.Equal(t, 1, hub.Push("schedulehub", "updateGame", map[string]interface{}{"GameKey": "58155"}))
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "updateGame", msg.M)
+		require.Len(t, msg.A, 1)
+		assert.JSONEq(t, `{"GameKey":"58155"}`, string(msg.A[0]))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for push")
+	}
+}
+
+func TestSignalRHub_Delay(t *testing.T) {
+	hub := gsistest.NewSignalRHub()
+	defer hub.Close()
+	hub.Handle("gamestatshub", "RegisterForStats", func(args []json.RawMessage) (interface{}, error) {
+		return "stats", nil
+	})
+	hub.SetDelay(time.Second)
+
+	c := newClient(hub)
+	defer c.Close()
+
+	conn, err := c.Connection()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = conn.Invoke(ctx, "gamestatshub", "RegisterForStats", "58155")
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Len(t, hub.WaitForInvocations("gamestatshub", "RegisterForStats", 1, 5*time.Second), 1)
+}
+
+func TestSignalRHub_Disconnect(t *testing.T) {
+	hub := gsistest.NewSignalRHub()
+	defer hub.Close()
+	hub.SetDelay(time.Minute)
+
+	c := newClient(hub)
+	defer c.Close()
+
+	conn, err := c.Connection()
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		_, err := conn.Invoke(context.Background(), "gamestatshub", "RegisterForStats", "58155")
+		done <- err
+	}()
+
+	hub.WaitForInvocations("gamestatshub", "RegisterForStats", 1, 5*time.Second)
+	hub.Disconnect()
+
+	select {
+	case err := <-done:
+		assert.Equal(t, gsis.ErrSignalRConnectionClosed, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for invocation to fail")
+	}
+	require.Eventually(t, func() bool {
+		return hub.Connections() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The client reconnects on demand.
+	reconnected, err := c.Connection()
+	require.NoError(t, err)
+	assert.NotSame(t, conn, reconnected)
+	assert.Equal(t, 2, hub.Negotiations())
+}
+
+func TestSignalRHub_FailNegotiations(t *testing.T) {
+	hub := gsistest.NewSignalRHub()
+	defer hub.Close()
+	hub.FailNegotiations(1)
+
+	c := newClient(hub)
+	defer c.Close()
+
+	// The client retries failed negotiations.
+	_, err := c.Connection()
+	require.NoError(t, err)
+	assert.Equal(t, 1, hub.Negotiations())
+}
+
+func TestSignalRHub_Abort(t *testing.T) {
+	hub := gsistest.NewSignalRHub()
+	defer hub.Close()
+
+	c := newClient(hub)
+	defer c.Close()
+
+	conn, err := c.Connection()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return hub.Connections() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	resp, err := http.Post(hub.URL+"/abort?"+url.Values{
+		"transport":       []string{"webSockets"},
+		"connectionToken": []string{"1"},
+	}.Encode(), "text/plain", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Eventually(t, conn.IsClosed, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSignalRHub_InvocationOrder(t *testing.T) {
+	hub := gsistest.NewSignalRHub()
+	defer hub.Close()
+	hub.Handle("gamestatshub", "RegisterForStats", func(args []json.RawMessage) (interface{}, error) {
+		return nil, nil
+	})
+
+	// Write the invocations directly so that they arrive back to back.
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(hub.URL, "http")+"/connect?"+url.Values{
+		"transport":       []string{"webSockets"},
+		"connectionToken": []string{"1"},
+	}.Encode(), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	const n = 50
+	for i := 0; i < n; i++ {
+		require.NoError(t, conn.WriteJSON(map[string]interface{}{
+			"H": "gamestatshub",
+			"M": "RegisterForStats",
+			"A": []int{i},
+			"I": i,
+		}))
+	}
+
+	invocations := hub.WaitForInvocations("gamestatshub", "RegisterForStats", n, 5*time.Second)
+	require.Len(t, invocations, n)
+	for i, invocation := range invocations {
+		assert.Equal(t, fmt.Sprintf("[%v]", i), mustMarshal(t, invocation.Args))
+	}
+}
+
+func TestSignalRHub_NoKeepAlive(t *testing.T) {
+	hub := gsistest.NewSignalRHub()
+	defer hub.Close()
+	hub.SetKeepAlive(0)
+	hub.Handle("gamestatshub", "RegisterForStats", func(args []json.RawMessage) (interface{}, error) {
+		return "stats", nil
+	})
+
+	c := newClient(hub)
+	defer c.Close()
+
+	conn, err := c.Connection()
+	require.NoError(t, err)
+	resp, err := conn.Invoke(context.Background(), "gamestatshub", "RegisterForStats", "58155")
+	require.NoError(t, err)
+	assert.JSONEq(t, `"stats"`, string(resp))
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	buf, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(buf)
+}
@@ -0,0 +1,216 @@
+package gsis
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type OfficialPosition string
+
+const (
+	OfficialPositionReferee        OfficialPosition = "REFEREE"
+	OfficialPositionUmpire         OfficialPosition = "UMPIRE"
+	OfficialPositionHeadLinesman   OfficialPosition = "HEAD_LINESMAN"
+	OfficialPositionDownJudge      OfficialPosition = "DOWN_JUDGE"
+	OfficialPositionLineJudge      OfficialPosition = "LINE_JUDGE"
+	OfficialPositionFieldJudge     OfficialPosition = "FIELD_JUDGE"
+	OfficialPositionSideJudge      OfficialPosition = "SIDE_JUDGE"
+	OfficialPositionBackJudge      OfficialPosition = "BACK_JUDGE"
+	OfficialPositionReplayOfficial OfficialPosition = "REPLAY_OFFICIAL"
+)
+
+// The positions in the order they appear in the game attributes.
+var OfficialPositions = []OfficialPosition{
+	OfficialPositionReferee,
+	OfficialPositionUmpire,
+	OfficialPositionHeadLinesman,
+	OfficialPositionDownJudge,
+	OfficialPositionLineJudge,
+	OfficialPositionFieldJudge,
+	OfficialPositionSideJudge,
+	OfficialPositionBackJudge,
+	OfficialPositionReplayOfficial,
+}
+
+// A game worked by a crew.
+type OfficialsGame struct {
+	GameKey         int
+	Season          int
+	SeasonType      string
+	Week            int
+	HomeClubCode    string
+	VisitorClubCode string
+
+	// Zero if the file doesn't have a valid game date.
+	Date time.Time
+
+	Officials []StatFileOfficial
+}
+
+// Position returns the officials who worked the position.
+func (g *OfficialsGame) Position(position OfficialPosition) []StatFileOfficial {
+	var ret []StatFileOfficial
+	for _, official := range g.Officials {
+		if official.Position == position {
+			ret = append(ret, official)
+		}
+	}
+	return ret
+}
+
+// A referee's crew for a season.
+type OfficialCrew struct {
+	Season  int
+	Referee StatFileOfficial
+
+	// In chronological order.
+	Games []*OfficialsGame
+}
+
+// A change to a crew's assignment at a position from one game to the next.
+type OfficialAssignmentChange struct {
+	// The first game with the new assignment.
+	Game *OfficialsGame
+
+	Position OfficialPosition
+
+	// Either may be empty if the position wasn't filled.
+	Previous []StatFileOfficial
+	Current  []StatFileOfficial
+}
+
+func officialKeys(officials []StatFileOfficial) string {
+	keys := make([]string, len(officials))
+	for i, official := range officials {
+		keys[i] = official.Key()
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, " & ")
+}
+
+// AssignmentChanges returns each change to the crew's assignments, such as a swing official filling
+// in or an official moving positions, in order.
+func (c *OfficialCrew) AssignmentChanges() []*OfficialAssignmentChange {
+	var ret []*OfficialAssignmentChange
+	for i := 1; i < len(c.Games); i++ {
+		previous, current := c.Games[i-1], c.Games[i]
+		for _, position := range OfficialPositions {
+			before, after := previous.Position(position), current.Position(position)
+			if officialKeys(before) != officialKeys(after) {
+				ret = append(ret, &OfficialAssignmentChange{
+					Game:     current,
+					Position: position,
+					Previous: before,
+					Current:  after,
+				})
+			}
+		}
+	}
+	return ret
+}
+
+// OfficialsRegistry collects the officiating crews from many games. Crews are identified by their
+// referee and season.
+type OfficialsRegistry struct {
+	crews map[string]*OfficialCrew
+	games map[int]bool
+}
+
+func NewOfficialsRegistry() *OfficialsRegistry {
+	return &OfficialsRegistry{
+		crews: map[string]*OfficialCrew{},
+		games: map[int]bool{},
+	}
+}
+
+// AddStatFile adds the game's officials to the registry. Files without a referee are ignored, as
+// are games that have already been added.
+func (r *OfficialsRegistry) AddStatFile(f *StatFile) {
+	if f.GameAttributes == nil || f.CumeStatHeader == nil {
+		return
+	}
+	h := f.CumeStatHeader
+	game := &OfficialsGame{
+		GameKey:         int(h.GameKey),
+		Season:          int(h.Season),
+		SeasonType:      h.SeasonType,
+		Week:            int(h.Week),
+		HomeClubCode:    h.HomeClubCode,
+		VisitorClubCode: h.VisitorClubCode,
+		Officials:       f.GameAttributes.Officials(),
+	}
+	if date, err := time.Parse("01/02/2006", h.Game_Date); err == nil {
+		game.Date = date
+	}
+	referees := game.Position(OfficialPositionReferee)
+	if len(referees) == 0 {
+		return
+	}
+	if game.GameKey != 0 {
+		if r.games[game.GameKey] {
+			return
+		}
+		r.games[game.GameKey] = true
+	}
+
+	referee := referees[0]
+	key := strconv.Itoa(game.Season) + "/" + referee.Key()
+	crew, ok := r.crews[key]
+	if !ok {
+		crew = &OfficialCrew{
+			Season:  game.Season,
+			Referee: referee,
+		}
+		r.crews[key] = crew
+	}
+	crew.Games = append(crew.Games, game)
+	sort.SliceStable(crew.Games, func(i, j int) bool {
+		a, b := crew.Games[i], crew.Games[j]
+		if !a.Date.Equal(b.Date) && !a.Date.IsZero() && !b.Date.IsZero() {
+			return a.Date.Before(b.Date)
+		}
+		return a.GameKey < b.GameKey
+	})
+}
+
+// Crews returns every crew, ordered by season and then by the referee's last and first name.
+func (r *OfficialsRegistry) Crews() []*OfficialCrew {
+	ret := make([]*OfficialCrew, 0, len(r.crews))
+	for _, crew := range r.crews {
+		ret = append(ret, crew)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := ret[i], ret[j]
+		if a.Season != b.Season {
+			return a.Season < b.Season
+		} else if a.Referee.LastName != b.Referee.LastName {
+			return a.Referee.LastName < b.Referee.LastName
+		} else if a.Referee.FirstName != b.Referee.FirstName {
+			return a.Referee.FirstName < b.Referee.FirstName
+		}
+		return a.Referee.JerseyNumber < b.Referee.JerseyNumber
+	})
+	return ret
+}
+
+// OfficialGames returns the games an official worked at any position, in chronological order.
+func (r *OfficialsRegistry) OfficialGames(official StatFileOfficial) []*OfficialsGame {
+	var ret []*OfficialsGame
+	key := official.Key()
+	for _, crew := range r.Crews() {
+		for _, game := range crew.Games {
+			for _, o := range game.Officials {
+				if o.Key() == key {
+					ret = append(ret, game)
+					break
+				}
+			}
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Date.Before(ret[j].Date)
+	})
+	return ret
+}
@@ -0,0 +1,124 @@
+package gsis
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfficialsRegistry(t *testing.T) {
+	game := func(gameKey int, date string, attributes StatFileGameAttributes) *StatFile {
+		return &StatFile{
+			CumeStatHeader: &StatFileCumeStatHeader{
+				Season:     2019,
+				SeasonType: "Reg",
+				Game_Date:  date,
+				GameKey:    StringInt(gameKey),
+			},
+			GameAttributes: &attributes,
+		}
+	}
+
+	r := NewOfficialsRegistry()
+	r.AddStatFile(game(2, "09/15/2019", StatFileGameAttributes{
+		Referee:   "Martin, Clay (19)",
+		Umpire:    "Paganelli, Carl (124)",
+		SideJudge: "Coleman, Walt IV (87)",
+	}))
+	r.AddStatFile(game(1, "09/08/2019", StatFileGameAttributes{
+		Referee: "Clay Martin (19)",
+		Umpire:  "Paganelli, Carl (124)",
+	}))
+	r.AddStatFile(game(3, "09/22/2019", StatFileGameAttributes{
+		Referee: "Hussey, John (35)",
+		Umpire:  "Paganelli, Carl (124)",
+	}))
+
+	// Duplicates and games without a referee are ignored.
+	r.AddStatFile(game(1, "09/08/2019", StatFileGameAttributes{
+		Referee: "Clay Martin (19)",
+	}))
+	r.AddStatFile(game(4, "09/29/2019", StatFileGameAttributes{
+		Umpire: "Paganelli, Carl (124)",
+	}))
+	r.AddStatFile(&StatFile{})
+
+	crews := r.Crews()
+	require.Len(t, crews, 2)
+	assert.Equal(t, "Hussey", crews[0].Referee.LastName)
+	assert.Equal(t, 2019, crews[1].Season)
+	assert.Equal(t, "Clay Martin", crews[1].Referee.Name())
+	require.Len(t, crews[1].Games, 2)
+	assert.Equal(t, 1, crews[1].Games[0].GameKey)
+	assert.Equal(t, 2, crews[1].Games[1].GameKey)
+
+	changes := crews[1].AssignmentChanges()
+	require.Len(t, changes, 1)
+	assert.Equal(t, 2, changes[0].Game.GameKey)
+	assert.Equal(t, OfficialPositionSideJudge, changes[0].Position)
+	assert.Empty(t, changes[0].Previous)
+	require.Len(t, changes[0].Current, 1)
+	assert.Equal(t, "Walt Coleman IV", changes[0].Current[0].Name())
+
+	umpire := StatFileOfficials("Carl Paganelli (124)").Officials()[0]
+	games := r.OfficialGames(umpire)
+	require.Len(t, games, 3)
+	assert.Equal(t, 1, games[0].GameKey)
+	assert.Equal(t, 3, games[2].GameKey)
+}
+
+func TestOfficialsRegistry_Games(t *testing.T) {
+	r := NewOfficialsRegistry()
+	keys := map[string]map[string]bool{}
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		r.AddStatFile(stats)
+		if stats.GameAttributes != nil {
+			for _, official := range stats.GameAttributes.Officials() {
+				name := strings.ToLower(official.FirstName + " " + official.LastName)
+				if keys[name] == nil {
+					keys[name] = map[string]bool{}
+				}
+				keys[name][official.Key()] = true
+			}
+		}
+	})
+
+	// Officials keep their numbers, so each one should only have one key. A few games leave out
+	// numbers that the same officials have in other games, which gives them a second key without a
+	// number.
+	require.NotEmpty(t, keys)
+	for name, nameKeys := range keys {
+		if nameKeys[name+" ()"] && len(nameKeys) > 1 {
+			delete(nameKeys, name+" ()")
+		}
+		switch name {
+		case "walt coleman":
+			// Walt Coleman and Walt Coleman IV, who is sometimes listed without his suffix.
+			assert.Len(t, nameKeys, 2, name)
+		case "todd prukop":
+			// One game lists him as 112 instead of 30.
+			assert.Len(t, nameKeys, 2, name)
+		default:
+			assert.Len(t, nameKeys, 1, name)
+		}
+	}
+
+	crews := r.Crews()
+	require.NotEmpty(t, crews)
+	for _, crew := range crews {
+		// Each crew works one game a week at most.
+		weeks := map[string]bool{}
+		for i, game := range crew.Games {
+			week := fmt.Sprintf("%v/%v", game.SeasonType, game.Week)
+			assert.False(t, weeks[week], "%v worked twice in %v", crew.Referee.Name(), week)
+			weeks[week] = true
+			if i > 0 {
+				assert.False(t, game.Date.Before(crew.Games[i-1].Date))
+			}
+			assert.Equal(t, crew.Referee.Key(), game.Position(OfficialPositionReferee)[0].Key())
+		}
+	}
+}
@@ -2,7 +2,6 @@ package gsis
 
 import (
 	"regexp"
-	"sort"
 	"strconv"
 	"strings"
 	"unicode"
@@ -71,106 +70,6 @@ const (
 	FoulCodeUseOfHelmet                FoulCode = "UOH"
 )
 
-// Returns a foul code based on its description text. Multiple descriptions may correspond to a
-// single foul code, so if you need the canonical description for a foul code, use
-// (FoulCode).Description.
-var FoulCodesByDescription = map[string]FoulCode{
-	"chop block":                            FoulCode("CHB"),
-	"illegal substitution":                  FoulCode("ILS"),
-	"clipping":                              FoulCode("CLP"),
-	"illegal touch kick":                    FoulCode("ITK"),
-	"defensive delay of game":               FoulCode("DOD"),
-	"illegal touch pass":                    FoulCode("ITP"),
-	"defensive holding":                     FoulCode("DH"),
-	"illegal use of hands":                  FoulCode("ILH"),
-	"defensive offside":                     FoulCode("DOF"),
-	"illegal wedge":                         FoulCode("WED"),
-	"defensive pass interference":           FoulCode("DPI"),
-	"ineligible downfield kick":             FoulCode("IDK"),
-	"defensive too many men on field":       FoulCode("DTM"),
-	"ineligible downfield pass":             FoulCode("IDP"),
-	"delay of game":                         FoulCode("DOG"),
-	"intentional grounding":                 FoulCode("ING"),
-	"delay of kickoff":                      FoulCode("DOK"),
-	"invalid fair catch signal":             FoulCode("IFC"),
-	"disqualification":                      FoulCode("DSQ"),
-	"kick catch interference":               FoulCode("KCI"),
-	"encroachment":                          FoulCode("ENC"),
-	"kickoff out of bounds":                 FoulCode("KOB"),
-	"facemask":                              FoulCode("FMM"),
-	"face mask (15 yards)":                  FoulCode("FMM"),
-	"leaping":                               FoulCode("LEA"),
-	"fair catch interference":               FoulCode("FCI"),
-	"leverage":                              FoulCode("LEV"),
-	"false start":                           FoulCode("FST"),
-	"low block":                             FoulCode("LBL"),
-	"horse collar":                          FoulCode("HC"),
-	"horse collar tackle":                   FoulCode("HC"),
-	"neutral zone infraction":               FoulCode("NZI"),
-	"illegal bat":                           FoulCode("BAT"),
-	"offensive holding":                     FoulCode("OH"),
-	"illegal blindside block":               FoulCode("BLI"),
-	"offensive offside":                     FoulCode("OOF"),
-	"illegal block above the waist":         FoulCode("IBW"),
-	"offensive pass interference":           FoulCode("OPI"),
-	"illegal contact":                       FoulCode("ICT"),
-	"offensive too many men on field":       FoulCode("OTM"),
-	"illegal crackback":                     FoulCode("ICB"),
-	"offside on free kick":                  FoulCode("OFK"),
-	"illegal cut":                           FoulCode("ICU"),
-	"player out of bounds on kick":          FoulCode("POK"),
-	"illegal double team block":             FoulCode("IDT"),
-	"roughing the kicker":                   FoulCode("RRK"),
-	"illegal formation":                     FoulCode("ILF"),
-	"roughing the passer":                   FoulCode("RPS"),
-	"illegal forward handoff":               FoulCode("IFH"),
-	"running into the kicker":               FoulCode("RNK"),
-	"illegal forward pass":                  FoulCode("IFP"),
-	"taunting":                              FoulCode("TAU"),
-	"illegal kick/kicking loose ball":       FoulCode("KIK"),
-	"tripping":                              FoulCode("TRP"),
-	"illegal motion":                        FoulCode("ILM"),
-	"unnecessary roughness":                 FoulCode("UNR"),
-	"illegal peel back":                     FoulCode("IPB"),
-	"unsportsmanlike conduct":               FoulCode("UNS"),
-	"illegal shift":                         FoulCode("ISH"),
-	"use of helmet":                         FoulCode("UOH"),
-	"lowering the head to initiate contact": FoulCode("UOH"),
-}
-
-var foulCodeDescriptions map[FoulCode]string
-
-var FoulCodes []FoulCode
-
-func init() {
-	foulCodeDescriptions = map[FoulCode]string{}
-	for desc, code := range FoulCodesByDescription {
-		if _, ok := foulCodeDescriptions[code]; !ok {
-			FoulCodes = append(FoulCodes, code)
-		}
-		switch code {
-		case FoulCodeHorseCollar:
-			foulCodeDescriptions[code] = "horse collar"
-		case FoulCodeFacemask:
-			foulCodeDescriptions[code] = "facemask"
-		case FoulCodeUseOfHelmet:
-			foulCodeDescriptions[code] = "use of helmet"
-		default:
-			if _, ok := foulCodeDescriptions[code]; ok {
-				panic("foul code with multiple descriptions must have explicit description case: " + string(code))
-			}
-			foulCodeDescriptions[code] = desc
-		}
-	}
-	sort.Slice(FoulCodes, func(i, j int) bool {
-		return FoulCodes[i] < FoulCodes[j]
-	})
-}
-
-func (c FoulCode) Description() string {
-	return foulCodeDescriptions[c]
-}
-
 type OffsettingPenalty struct {
 	FoulCode     FoulCode
 	Team         string
@@ -182,7 +81,11 @@ type PenaltyEnforcementSpot int
 const (
 	PenaltyEnforcementSpotNone PenaltyEnforcementSpot = iota
 	PenaltyEnforcementSpotPrevious
+
+	// Descriptions don't say whether a foul was a dead ball foul, so the parser never returns this.
+	// EnforcePenalties infers it from the foul's rule.
 	PenaltyEnforcementSpotDeadBall
+
 	PenaltyEnforcementSpotSucceeding
 	PenaltyEnforcementSpotOther
 )
@@ -195,6 +98,18 @@ type PenaltyInfo struct {
 	EnforcementSpot PenaltyEnforcementSpot
 	EnforcedAt      *YardLine
 	Distance        int
+
+	// These are only set by (*StatFilePlay).Penalties. The player ID and yards come from the
+	// penalty stat, so they're empty for penalties that weren't credited, e.g. declined ones.
+	GSISPlayerID string
+	Yards        int
+
+	// True if the penalty caused the play to be nullified.
+	PlayNullified bool
+
+	// The stats wiped out by the penalty, either because the play was nullified or because they
+	// came after the spot of the foul.
+	NullifiedStats []StatFilePlayStat
 }
 
 type PenaltyStatus string
@@ -205,19 +120,122 @@ const (
 	PenaltyStatusOffsetting PenaltyStatus = "OFFSETTING"
 )
 
+type PenaltyWarning string
+
+const (
+	PenaltyWarningUnknownFoul         PenaltyWarning = "UNKNOWN_FOUL"
+	PenaltyWarningUnknownTeam         PenaltyWarning = "UNKNOWN_TEAM"
+	PenaltyWarningConflictingStatus   PenaltyWarning = "CONFLICTING_STATUS"
+	PenaltyWarningUnparsedFragment    PenaltyWarning = "UNPARSED_FRAGMENT"
+	PenaltyWarningDiscardedIncomplete PenaltyWarning = "DISCARDED_INCOMPLETE"
+)
+
+// How much each warning reduces a penalty's confidence, in tenths. Integers keep the result exact,
+// e.g. 0.7 rather than 0.7000000000000001.
+var penaltyWarningPenalties = map[PenaltyWarning]int{
+	// Without a foul, the penalty is of little use.
+	PenaltyWarningUnknownFoul: 5,
+	// The foul is known, but not who committed it.
+	PenaltyWarningUnknownTeam: 3,
+	// Either the status or the yardage is wrong.
+	PenaltyWarningConflictingStatus: 3,
+	// Each fragment that wasn't understood might have changed the meaning of the rest.
+	PenaltyWarningUnparsedFragment: 1,
+	// The last penalty in the text was missing its foul or team, so it was left out.
+	PenaltyWarningDiscardedIncomplete: 5,
+}
+
+// A parsed penalty along with anything that went wrong parsing it.
+type PenaltyDiagnostic struct {
+	Penalty PenaltyInfo
+
+	// Warnings may repeat, e.g. once for each unparsed fragment.
+	Warnings []PenaltyWarning
+
+	// The fragments of the penalty's text that weren't understood.
+	Unparsed []string
+
+	// From 0 to 1, where 1 means everything in the penalty's text was understood.
+	Confidence float64
+
+	// True if the penalty was left out of ParsePlayDescriptionPenalties's results.
+	Discarded bool
+}
+
+func (d *PenaltyDiagnostic) HasWarning(warning PenaltyWarning) bool {
+	for _, w := range d.Warnings {
+		if w == warning {
+			return true
+		}
+	}
+	return false
+}
+
+type PenaltyDiagnostics struct {
+	// Every penalty found, including any that were discarded.
+	Penalties []*PenaltyDiagnostic
+
+	// Fragments that weren't understood, in order. These include fragments in the penalties'
+	// text.
+	Unparsed []string
+
+	// Fragments that are known GSIS noise, such as "penalty enforced".
+	Ignored []string
+}
+
 func ParsePlayDescriptionPenalties(description string) []PenaltyInfo {
+	var ret []PenaltyInfo
+	for _, d := range ParsePlayDescriptionPenaltiesWithDiagnostics(description).Penalties {
+		if !d.Discarded {
+			ret = append(ret, d.Penalty)
+		}
+	}
+	return ret
+}
+
+// ParsePlayDescriptionPenaltiesWithDiagnostics parses penalties like ParsePlayDescriptionPenalties,
+// but also reports what it didn't understand. It's intended for finding new phrasing GSIS
+// introduces.
+func ParsePlayDescriptionPenaltiesWithDiagnostics(description string) *PenaltyDiagnostics {
+	ret := &PenaltyDiagnostics{}
+
 	// Added to remove GSIS description noise
 	cleanedDescription := strings.ReplaceAll(description, "TOUCHDOWN NULLIFIED by Penalty [", "TOUCHDOWN NULLIFIED by [")
 	parts := strings.SplitN(strings.ToLower(cleanedDescription), "penalty ", 2)
 	if len(parts) == 1 {
-		return nil
+		return ret
 	}
 	cleanedDescription = "penalty " + parts[1]
 
-	var ret []PenaltyInfo
-
-	newPenalty := PenaltyInfo{
-		Status: PenaltyStatusAccepted,
+	current := &PenaltyDiagnostic{
+		Penalty: PenaltyInfo{
+			Status: PenaltyStatusAccepted,
+		},
+	}
+	var statuses []PenaltyStatus
+	finish := func() {
+		p := &current.Penalty
+		if p.FoulCode == "" {
+			current.Warnings = append(current.Warnings, PenaltyWarningUnknownFoul)
+		}
+		if !playPlayerTeamRegexp.MatchString(p.Team) {
+			current.Warnings = append(current.Warnings, PenaltyWarningUnknownTeam)
+		}
+		if len(statuses) > 1 || (len(statuses) == 1 && statuses[0] == PenaltyStatusDeclined && p.Distance != 0) {
+			current.Warnings = append(current.Warnings, PenaltyWarningConflictingStatus)
+		}
+		if current.Discarded {
+			current.Warnings = append(current.Warnings, PenaltyWarningDiscardedIncomplete)
+		}
+		tenths := 10
+		for _, w := range current.Warnings {
+			tenths -= penaltyWarningPenalties[w]
+		}
+		if tenths < 0 {
+			tenths = 0
+		}
+		current.Confidence = float64(tenths) / 10
+		ret.Penalties = append(ret.Penalties, current)
 	}
 
 	splitDesc := regexp.MustCompile(`[,.]\s`).Split(cleanedDescription, -1)
@@ -233,6 +251,7 @@ func ParsePlayDescriptionPenalties(description string) []PenaltyInfo {
 
 		// Remove GSIS noise section
 		if part == "penalty enforced" {
+			ret.Ignored = append(ret.Ignored, part)
 			continue
 		}
 
@@ -240,16 +259,22 @@ func ParsePlayDescriptionPenalties(description string) []PenaltyInfo {
 		if strings.Contains(part, "penalty ") && idx > 0 {
 			// Catch cases where it says "penalty enforced" before actual penalty (1 seen so far)
 			if !(idx == 1 && splitDesc[0] == "penalty enforced") {
-				ret = append(ret, newPenalty)
-				newPenalty = PenaltyInfo{
-					Status: PenaltyStatusAccepted,
+				finish()
+				current = &PenaltyDiagnostic{
+					Penalty: PenaltyInfo{
+						Status: PenaltyStatusAccepted,
+					},
 				}
+				statuses = nil
 			}
 		}
+		newPenalty := &current.Penalty
+		consumed := false
 
 		// Add foul code info
-		if code, ok := FoulCodesByDescription[strings.ReplaceAll(part, "-", " ")]; ok {
+		if code, ok := FoulCodeForDescription(part); ok {
 			newPenalty.FoulCode = code
+			consumed = true
 		}
 
 		// Add player/team info
@@ -265,10 +290,12 @@ func ParsePlayDescriptionPenalties(description string) []PenaltyInfo {
 				}
 				newPenalty.JerseyNumber = number
 			}
+			consumed = true
 		}
 
 		if part == "enforced between downs" {
 			newPenalty.EnforcementSpot = PenaltyEnforcementSpotSucceeding
+			consumed = true
 		}
 
 		if strings.HasPrefix(part, "enforced at ") {
@@ -276,40 +303,136 @@ func ParsePlayDescriptionPenalties(description string) []PenaltyInfo {
 			yardLineString = strings.Split(yardLineString, " - ")[0]
 			parts := strings.Split(yardLineString, " ")
 			if number, err := strconv.Atoi(parts[len(parts)-1]); err == nil {
-				l := &YardLine{
-					number: &number,
-				}
+				team := ""
 				if len(parts) > 1 {
-					team := strings.ToUpper(parts[0])
-					l.team = &team
+					team = strings.ToUpper(parts[0])
 				}
-				newPenalty.EnforcedAt = l
+				newPenalty.EnforcedAt = NewYardLine(team, number)
 				newPenalty.EnforcementSpot = PenaltyEnforcementSpotOther
+				consumed = true
 			}
 		}
 
+		// The fouling team's own end zone. For the offense this means a safety, which is also
+		// mentioned on its own.
+		if part == "enforced in end zone" && newPenalty.Team != "" {
+			newPenalty.EnforcedAt = NewYardLine(newPenalty.Team, 0)
+			newPenalty.EnforcementSpot = PenaltyEnforcementSpotOther
+			consumed = true
+		}
+		if part == "safety" && newPenalty.EnforcedAt != nil && newPenalty.EnforcedAt.Number() == 0 {
+			consumed = true
+		}
+
 		if strings.HasSuffix(part, " yards") {
 			yardsString := strings.TrimSuffix(part, " yards")
 			if n, err := strconv.Atoi(yardsString); err == nil {
 				newPenalty.Distance = n
+				consumed = true
 			}
 		}
 
 		if strings.HasSuffix(part, "no play") {
 			newPenalty.EnforcementSpot = PenaltyEnforcementSpotPrevious
+			consumed = true
 		}
 
 		// Update status if necessary
 		if strings.Contains(part, "declined") {
 			newPenalty.Status = PenaltyStatusDeclined
+			statuses = append(statuses, PenaltyStatusDeclined)
+			consumed = true
 		} else if strings.Contains(part, "offsetting") {
 			newPenalty.Status = PenaltyStatusOffsetting
+			statuses = append(statuses, PenaltyStatusOffsetting)
+			consumed = true
+		}
+
+		if !consumed && part != "" {
+			current.Warnings = append(current.Warnings, PenaltyWarningUnparsedFragment)
+			current.Unparsed = append(current.Unparsed, part)
+			ret.Unparsed = append(ret.Unparsed, part)
 		}
 	}
 
 	// Append last penalty of play if valid (in case GSIS adds random penalty text somewhere)
-	if newPenalty.FoulCode != "" && newPenalty.Team != "" {
-		ret = append(ret, newPenalty)
+	if current.Penalty.FoulCode == "" || current.Penalty.Team == "" {
+		current.Discarded = true
+	}
+	finish()
+	return ret
+}
+
+// Penalties returns the penalties called on the play, joined to the penalty stats credited for
+// them and the stats they nullified. The stats may include rows for other plays, which are ignored.
+// Mentions of penalties that don't name a foul and a team are skipped.
+func (p *StatFilePlay) Penalties(stats, nullifiedStats []StatFilePlayStat) []PenaltyInfo {
+	description := p.PlayDescriptionWithJerseyNumbers
+	if description == "" {
+		description = p.PlayDescription
+	}
+	var ret []PenaltyInfo
+	for _, d := range ParsePlayDescriptionPenaltiesWithDiagnostics(description).Penalties {
+		// Text that mentions a penalty without describing one, such as "Touchback due to penalty in
+		// end zone", isn't a penalty.
+		if d.Discarded || d.HasWarning(PenaltyWarningUnknownFoul) || d.HasWarning(PenaltyWarningUnknownTeam) {
+			continue
+		}
+		ret = append(ret, d.Penalty)
+	}
+	if len(ret) == 0 {
+		return nil
+	}
+
+	var penaltyStats, nullified []StatFilePlayStat
+	for _, stat := range stats {
+		if stat.PlayID == p.PlayID && stat.StatID == StatIDPenalty {
+			penaltyStats = append(penaltyStats, stat)
+		}
+	}
+	for _, stat := range nullifiedStats {
+		if stat.PlayID == p.PlayID {
+			nullified = append(nullified, stat)
+		}
+	}
+
+	noPlay := ParsePlayDescription(description).NoPlay
+	used := make([]bool, len(penaltyStats))
+	playNullified := false
+	for i := range ret {
+		penalty := &ret[i]
+
+		// Only accepted penalties are credited.
+		if penalty.Status == PenaltyStatusAccepted {
+			for j, stat := range penaltyStats {
+				if used[j] || CommonTeamAbbreviation(stat.ClubCode) != CommonTeamAbbreviation(penalty.Team) {
+					continue
+				}
+				if penalty.JerseyNumber != "" && normalizeJerseyNumber(stat.UniformNumber) != normalizeJerseyNumber(penalty.JerseyNumber) {
+					continue
+				}
+				used[j] = true
+				penalty.GSISPlayerID = stat.PlayerID
+				penalty.Yards = stat.Yards.Int()
+				break
+			}
+		}
+
+		switch penalty.Status {
+		case PenaltyStatusAccepted:
+			penalty.PlayNullified = penalty.EnforcementSpot == PenaltyEnforcementSpotPrevious
+		case PenaltyStatusOffsetting:
+			penalty.PlayNullified = noPlay
+		}
+		playNullified = playNullified || penalty.PlayNullified
+	}
+
+	// If the play stands, stats after the spot of an accepted foul can still be wiped out.
+	for i := range ret {
+		penalty := &ret[i]
+		if penalty.PlayNullified || (!playNullified && penalty.Status == PenaltyStatusAccepted && penalty.EnforcementSpot == PenaltyEnforcementSpotOther) {
+			penalty.NullifiedStats = nullified
+		}
 	}
 	return ret
 }
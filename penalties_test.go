@@ -2,7 +2,9 @@ package gsis
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"testing"
 
@@ -10,13 +12,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func NewYardLine(team string, number int) *YardLine {
-	return &YardLine{
-		team:   &team,
-		number: &number,
-	}
-}
-
 // Many of these have "previous" for the enforcement spot, when technically the penalty is
 // enforced from elsewhere. The GSIS Guide to Events and Attributes explains why:
 //
@@ -292,7 +287,216 @@ func TestParsePlayDescriptionPenalties(t *testing.T) {
 			EnforcedAt:      NewYardLine("CIN", 5),
 			Distance:        4,
 		}},
+		"(4:27) (Shotgun) 6-D.Hodges pass incomplete short right [54-B.Carr]. PENALTY on PIT-6-D.Hodges, Intentional Grounding, 6 yards, enforced in End Zone, SAFETY.": []PenaltyInfo{{
+			Status:          PenaltyStatusAccepted,
+			FoulCode:        FoulCodeIntentionalGrounding,
+			Team:            "PIT",
+			JerseyNumber:    "06",
+			EnforcementSpot: PenaltyEnforcementSpotOther,
+			EnforcedAt:      NewYardLine("PIT", 0),
+			Distance:        6,
+		}},
 	} {
 		assert.Equal(t, expected, ParsePlayDescriptionPenalties(desc), desc)
 	}
 }
+
+func TestParsePlayDescriptionPenaltiesWithDiagnostics(t *testing.T) {
+	t.Run("Clean", func(t *testing.T) {
+		d := ParsePlayDescriptionPenaltiesWithDiagnostics("(12:12) (Shotgun) PENALTY on LA-76-R.Havenstein, False Start, 5 yards, enforced at LA 25 - No Play.")
+		require.Len(t, d.Penalties, 1)
+		assert.Equal(t, FoulCodeFalseStart, d.Penalties[0].Penalty.FoulCode)
+		assert.Empty(t, d.Penalties[0].Warnings)
+		assert.Equal(t, 1.0, d.Penalties[0].Confidence)
+		assert.False(t, d.Penalties[0].Discarded)
+		assert.Empty(t, d.Unparsed)
+	})
+
+	t.Run("NoPenalties", func(t *testing.T) {
+		d := ParsePlayDescriptionPenaltiesWithDiagnostics("(15:00) J.Myers kicks 65 yards from SEA 35 to end zone, Touchback.")
+		assert.Empty(t, d.Penalties)
+		assert.Empty(t, d.Unparsed)
+	})
+
+	t.Run("ConflictingStatus", func(t *testing.T) {
+		d := ParsePlayDescriptionPenaltiesWithDiagnostics("PENALTY on NE-J.Smith, Offensive Holding, 10 yards, declined.")
+		require.Len(t, d.Penalties, 1)
+		assert.Equal(t, []PenaltyWarning{PenaltyWarningConflictingStatus}, d.Penalties[0].Warnings)
+		assert.Equal(t, 0.7, d.Penalties[0].Confidence)
+	})
+
+	t.Run("UnparsedFragments", func(t *testing.T) {
+		d := ParsePlayDescriptionPenaltiesWithDiagnostics("PENALTY on NE-J.Smith, Offensive Holding, at the line, before the snap, by the tight end, 10 yards, enforced at NE 20.")
+		require.Len(t, d.Penalties, 1)
+		assert.Len(t, d.Penalties[0].Unparsed, 3)
+		assert.Equal(t, 0.7, d.Penalties[0].Confidence)
+	})
+
+	t.Run("UnknownFoul", func(t *testing.T) {
+		// There's no space after the commas, so the foul, yards, and spot run together.
+		d := ParsePlayDescriptionPenaltiesWithDiagnostics("(9:51) D.Carr up the middle to IND 1 for no gain (D.Autry). PENALTY on IND-23-K.Moore II, Defensive Offside,0 yards,enforced at IND 1 - No Play.")
+		require.Len(t, d.Penalties, 1)
+		p := d.Penalties[0]
+		assert.Equal(t, "IND", p.Penalty.Team)
+		assert.True(t, p.HasWarning(PenaltyWarningUnknownFoul))
+		assert.False(t, p.HasWarning(PenaltyWarningUnknownTeam))
+		assert.True(t, p.Discarded)
+		assert.Equal(t, PenaltyEnforcementSpotPrevious, p.Penalty.EnforcementSpot)
+		assert.Equal(t, 0.0, p.Confidence)
+		assert.Empty(t, ParsePlayDescriptionPenalties("(9:51) D.Carr up the middle to IND 1 for no gain (D.Autry). PENALTY on IND-23-K.Moore II, Defensive Offside,0 yards,enforced at IND 1 - No Play."))
+	})
+
+	t.Run("TrailingNoise", func(t *testing.T) {
+		desc := "(13:34) (Shotgun) A.Dalton pass short left to G.Bernard pushed ob at CIN 30 for 4 yards (M.Lattimore; D.Davis).\nPenalty on CIN-T.Boyd, Offensive Pass Interference, declined. {penalty announced as #23, but was not in the play}"
+		d := ParsePlayDescriptionPenaltiesWithDiagnostics(desc)
+		require.Len(t, d.Penalties, 2)
+		assert.Equal(t, FoulCodeOffensivePassInterference, d.Penalties[0].Penalty.FoulCode)
+		assert.Equal(t, PenaltyStatusDeclined, d.Penalties[0].Penalty.Status)
+		assert.Equal(t, 1.0, d.Penalties[0].Confidence)
+		assert.True(t, d.Penalties[1].Discarded)
+		assert.Equal(t, []string{"{penalty announced as #23", "but was not in the play}"}, d.Unparsed)
+		assert.Len(t, ParsePlayDescriptionPenalties(desc), 1)
+	})
+
+	t.Run("Safety", func(t *testing.T) {
+		d := ParsePlayDescriptionPenaltiesWithDiagnostics("(8:13) B.Mayfield pass short left to N.Chubb to CLV 6 for 1 yard (V.Williams). PENALTY on CLV-D.Harrison, Offensive Holding, 5 yards, enforced in End Zone, SAFETY - No Play.")
+		require.Len(t, d.Penalties, 1)
+		assert.Empty(t, d.Penalties[0].Warnings)
+		assert.Equal(t, PenaltyEnforcementSpotPrevious, d.Penalties[0].Penalty.EnforcementSpot)
+		assert.Equal(t, NewYardLine("CLV", 0), d.Penalties[0].Penalty.EnforcedAt)
+		assert.Empty(t, d.Unparsed)
+	})
+
+	t.Run("Ignored", func(t *testing.T) {
+		d := ParsePlayDescriptionPenaltiesWithDiagnostics("Penalty enforced. PENALTY on DAL-58-J.Smith, Defensive Holding, 5 yards, enforced at LA 40 - No Play.")
+		require.Len(t, d.Penalties, 1)
+		assert.Equal(t, []string{"penalty enforced"}, d.Ignored)
+		assert.Empty(t, d.Unparsed)
+	})
+}
+
+func TestStatFilePlay_Penalties(t *testing.T) {
+	f, err := os.Open("testdata/signalr-stats.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	var stats StatFile
+	require.NoError(t, json.NewDecoder(f).Decode(&stats))
+
+	plays := map[int]*StatFilePlay{}
+	for _, p := range stats.Play {
+		plays[int(p.PlayID)] = p
+	}
+
+	t.Run("Player", func(t *testing.T) {
+		penalties := plays[99].Penalties(stats.PlayStat, stats.PlayStatNullified)
+		require.Len(t, penalties, 1)
+		assert.Equal(t, FoulCodeDefensiveHolding, penalties[0].FoulCode)
+		assert.Equal(t, "00-0027952", penalties[0].GSISPlayerID)
+		assert.Equal(t, 5, penalties[0].Yards)
+		assert.True(t, penalties[0].PlayNullified)
+		require.Len(t, penalties[0].NullifiedStats, 1)
+		assert.Equal(t, StatIDPassLengthNoCompletion, penalties[0].NullifiedStats[0].StatID)
+		assert.Equal(t, "J.Goff", penalties[0].NullifiedStats[0].PlayerName)
+	})
+
+	t.Run("Team", func(t *testing.T) {
+		penalties := plays[225].Penalties(stats.PlayStat, stats.PlayStatNullified)
+		require.Len(t, penalties, 1)
+		assert.Equal(t, FoulCodeDelayOfGame, penalties[0].FoulCode)
+		assert.Empty(t, penalties[0].GSISPlayerID)
+		assert.Equal(t, 5, penalties[0].Yards)
+		assert.True(t, penalties[0].PlayNullified)
+		assert.Empty(t, penalties[0].NullifiedStats)
+	})
+
+	t.Run("Offsetting", func(t *testing.T) {
+		penalties := plays[799].Penalties(stats.PlayStat, stats.PlayStatNullified)
+		require.Len(t, penalties, 2)
+		for _, penalty := range penalties {
+			assert.Equal(t, PenaltyStatusOffsetting, penalty.Status)
+			assert.Empty(t, penalty.GSISPlayerID)
+			assert.Zero(t, penalty.Yards)
+			assert.True(t, penalty.PlayNullified)
+			require.Len(t, penalty.NullifiedStats, 1)
+			assert.Equal(t, StatIDPassDefensed, penalty.NullifiedStats[0].StatID)
+		}
+	})
+
+	t.Run("HalfTheDistance", func(t *testing.T) {
+		// The description says "1 yard", which isn't parsed, but the stat has the yards.
+		penalties := plays[3853].Penalties(stats.PlayStat, stats.PlayStatNullified)
+		require.Len(t, penalties, 1)
+		assert.Equal(t, "00-0026618", penalties[0].GSISPlayerID)
+		assert.Equal(t, 1, penalties[0].Yards)
+		assert.True(t, penalties[0].PlayNullified)
+		require.Len(t, penalties[0].NullifiedStats, 1)
+	})
+
+	t.Run("None", func(t *testing.T) {
+		assert.Empty(t, plays[1005].Penalties(stats.PlayStat, stats.PlayStatNullified))
+	})
+}
+
+func TestStatFilePlay_Penalties_SpotFoul(t *testing.T) {
+	buf, err := ioutil.ReadFile("testdata/games/2018090909/GSISGameStats.xml")
+	require.NoError(t, err)
+
+	var stats StatFile
+	require.NoError(t, xml.Unmarshal(buf, &stats))
+
+	for _, p := range stats.Play {
+		if p.PlayID != 2247 {
+			continue
+		}
+
+		// A punt return with a declined penalty and a penalty enforced at the spot of the foul.
+		penalties := p.Penalties(stats.PlayStat, stats.PlayStatNullified)
+		require.Len(t, penalties, 2)
+
+		assert.Equal(t, PenaltyStatusDeclined, penalties[0].Status)
+		assert.Empty(t, penalties[0].GSISPlayerID)
+		assert.False(t, penalties[0].PlayNullified)
+		assert.Empty(t, penalties[0].NullifiedStats)
+
+		assert.Equal(t, FoulCodeUnnecessaryRoughness, penalties[1].FoulCode)
+		assert.Equal(t, PenaltyEnforcementSpotOther, penalties[1].EnforcementSpot)
+		assert.NotEmpty(t, penalties[1].GSISPlayerID)
+		assert.Equal(t, 5, penalties[1].Yards)
+		assert.False(t, penalties[1].PlayNullified)
+		assert.NotEmpty(t, penalties[1].NullifiedStats)
+		return
+	}
+	t.Fatal("play not found")
+}
+
+func TestStatFilePlay_Penalties_NotPenalties(t *testing.T) {
+	p := &StatFilePlay{
+		PlayID:          2485,
+		PlayDescription: "(9:08) 8-D.Kaser punts 58 yards to BUF 8, Center-47-M.Windt. 45-M.Murphy MUFFS catch, recovered by BUF-26-T.Jones at BUF 0. Touchback (42-U.Nwosu). Touchback due to penalty in end zone.\nPENALTY on LAC-42-U.Nwosu, Unnecessary Roughness, 15 yards, enforced at BUF 20.",
+	}
+	penalties := p.Penalties(nil, nil)
+	require.Len(t, penalties, 1)
+	assert.Equal(t, FoulCodeUnnecessaryRoughness, penalties[0].FoulCode)
+	assert.Equal(t, "LAC", penalties[0].Team)
+
+	p = &StatFilePlay{
+		PlayDescription: "Taunting Penalty on KC #10 T.Hill enforced on the Kickoff. Kickoff from the KC 20. 7-H.Butker kicks 80 yards from KC 20 to LA 0. 10-P.Cooper pushed ob at LA 25 for 25 yards (19-M.Kemp).",
+	}
+	assert.Empty(t, p.Penalties(nil, nil))
+}
+
+// A foul after a play that another foul nullified doesn't take the nullified stats.
+func TestStatFilePlay_Penalties_PostPlayFoul(t *testing.T) {
+	p := &StatFilePlay{
+		PlayID:          100,
+		PlayDescription: "(5:00) 22-C.McCaffrey up the middle to SEA 40 for 5 yards (54-B.Wagner). PENALTY on SF-71-T.Williams, Offensive Holding, 10 yards, enforced at SF 45 - No Play. PENALTY on SEA-54-B.Wagner, Unnecessary Roughness, 15 yards, enforced at SF 35.",
+	}
+	nullified := []StatFilePlayStat{{PlayID: 100, StatID: StatIDRushingYards, ClubCode: "SF"}}
+	penalties := p.Penalties(nil, nullified)
+	require.Len(t, penalties, 2)
+	assert.True(t, penalties[0].PlayNullified)
+	assert.Equal(t, nullified, penalties[0].NullifiedStats)
+	assert.False(t, penalties[1].PlayNullified)
+	assert.Empty(t, penalties[1].NullifiedStats)
+}
@@ -0,0 +1,380 @@
+package gsis
+
+import (
+	"sort"
+	"strings"
+)
+
+// A part of the field relative to the offense.
+type FieldZone string
+
+const (
+	// Inside the offense's own 20.
+	FieldZoneBackedUp FieldZone = "BACKED_UP"
+
+	// From the offense's own 20 up to midfield.
+	FieldZoneOwnTerritory FieldZone = "OWN_TERRITORY"
+
+	// From midfield up to the opponent's 20.
+	FieldZoneOpponentTerritory FieldZone = "OPPONENT_TERRITORY"
+
+	// The opponent's 20 and in.
+	FieldZoneRedZone FieldZone = "RED_ZONE"
+)
+
+// FieldZoneForDistance returns the zone for a distance from the offense's own goal line, as
+// returned by (*YardLine).Distance.
+func FieldZoneForDistance(distance int) FieldZone {
+	switch {
+	case distance < 20:
+		return FieldZoneBackedUp
+	case distance < 50:
+		return FieldZoneOwnTerritory
+	case distance < 80:
+		return FieldZoneOpponentTerritory
+	}
+	return FieldZoneRedZone
+}
+
+// A penalty along with the game situation it was called in.
+type PenaltyRecord struct {
+	Penalty PenaltyInfo
+	Play    *StatFilePlay
+
+	GameKey int
+
+	// The game's referee, which identifies the officiating crew. Referee is the referee's key, as
+	// returned by (StatFileOfficial).Key, and RefereeName is their name. Both are empty if the file
+	// has no game attributes.
+	Referee     string
+	RefereeName string
+
+	// The penalized player, found using the penalty stat or the game's stats for the player's team
+	// and jersey number. These are empty for penalties on a team.
+	PlayerID   string
+	PlayerName string
+
+	Offense string
+	Quarter int
+
+	// Zero for plays without a down, such as kickoffs and tries.
+	Down int
+
+	// Empty if the line of scrimmage is unknown.
+	Zone FieldZone
+}
+
+// PlayerKey identifies the penalized player. It's the GSIS player ID if it's known, otherwise the
+// team and jersey number. It's empty for penalties on a team.
+func (r *PenaltyRecord) PlayerKey() string {
+	switch {
+	case r.PlayerID != "":
+		return r.PlayerID
+	case r.Penalty.JerseyNumber != "":
+		return r.Penalty.Team + "-" + r.Penalty.JerseyNumber
+	}
+	return ""
+}
+
+type PenaltyTotals struct {
+	Penalties  int
+	Accepted   int
+	Declined   int
+	Offsetting int
+
+	// The yards from accepted penalties.
+	Yards int
+
+	FoulCodes map[FoulCode]int
+}
+
+func (t *PenaltyTotals) add(r *PenaltyRecord) {
+	t.Penalties++
+	switch r.Penalty.Status {
+	case PenaltyStatusAccepted:
+		t.Accepted++
+		yards := r.Penalty.Yards
+		if yards == 0 {
+			yards = r.Penalty.Distance
+		}
+		t.Yards += yards
+	case PenaltyStatusDeclined:
+		t.Declined++
+	case PenaltyStatusOffsetting:
+		t.Offsetting++
+	}
+	if t.FoulCodes == nil {
+		t.FoulCodes = map[FoulCode]int{}
+	}
+	t.FoulCodes[r.Penalty.FoulCode]++
+}
+
+type TeamPenaltyTotals struct {
+	ClubCode string
+
+	// The number of games the team played, including those without penalties.
+	Games int
+
+	PenaltyTotals
+}
+
+// The club code and jersey number are those of the player's first penalty.
+type PlayerPenaltyTotals struct {
+	// Empty if the player couldn't be identified.
+	PlayerID string
+
+	PlayerName   string
+	ClubCode     string
+	JerseyNumber string
+
+	PenaltyTotals
+}
+
+type CrewPenaltyTotals struct {
+	// The referee's key and name.
+	Referee     string
+	RefereeName string
+
+	// The number of games the crew worked, including those without penalties.
+	Games int
+
+	PenaltyTotals
+}
+
+// PenaltyFilter selects penalties by situation. Empty fields match everything.
+type PenaltyFilter struct {
+	Quarters []int
+	Downs    []int
+	Zones    []FieldZone
+}
+
+func (f PenaltyFilter) matches(r *PenaltyRecord) bool {
+	if len(f.Quarters) > 0 && !containsInt(f.Quarters, r.Quarter) {
+		return false
+	}
+	if len(f.Downs) > 0 && !containsInt(f.Downs, r.Down) {
+		return false
+	}
+	if len(f.Zones) > 0 {
+		for _, zone := range f.Zones {
+			if zone == r.Zone {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func containsInt(s []int, n int) bool {
+	for _, v := range s {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+// PenaltyAnalytics aggregates penalties across many games.
+type PenaltyAnalytics struct {
+	Records []*PenaltyRecord
+
+	teamGames map[string]int
+	crewGames map[string]int
+}
+
+func NewPenaltyAnalytics() *PenaltyAnalytics {
+	return &PenaltyAnalytics{
+		teamGames: map[string]int{},
+		crewGames: map[string]int{},
+	}
+}
+
+// AddStatFile adds a game's penalties. Deleted plays are ignored, as are mentions of penalties that
+// don't name a foul and a team.
+func (a *PenaltyAnalytics) AddStatFile(f *StatFile) {
+	var referee, refereeName string
+	if f.GameAttributes != nil {
+		if officials := f.GameAttributes.Referee.Officials(); len(officials) > 0 {
+			referee = officials[0].Key()
+			refereeName = officials[0].Name()
+		}
+	}
+	var gameKey int
+	if h := f.CumeStatHeader; h != nil {
+		gameKey = int(h.GameKey)
+		a.teamGames[h.HomeClubCode]++
+		a.teamGames[h.VisitorClubCode]++
+	}
+	if referee != "" {
+		a.crewGames[referee]++
+	}
+
+	stats := map[StringInt][]StatFilePlayStat{}
+	players := map[string]StatFilePlayStat{}
+	for _, stat := range f.PlayStat {
+		stats[stat.PlayID] = append(stats[stat.PlayID], stat)
+		if stat.PlayerID != "" && stat.UniformNumber != "" {
+			players[stat.ClubCode+"-"+normalizeJerseyNumber(stat.UniformNumber)] = stat
+		}
+	}
+	nullified := map[StringInt][]StatFilePlayStat{}
+	for _, stat := range f.PlayStatNullified {
+		nullified[stat.PlayID] = append(nullified[stat.PlayID], stat)
+	}
+
+	for _, p := range f.Play {
+		if p.PlayDeleted != 0 {
+			continue
+		}
+		penalties := p.Penalties(stats[p.PlayID], nullified[p.PlayID])
+		if len(penalties) == 0 {
+			continue
+		}
+		offense := strings.Trim(p.PossessionTeam, `"`)
+		var zone FieldZone
+		if distance, ok := p.YardLine.Distance(offense); ok && offense != "" {
+			zone = FieldZoneForDistance(distance)
+		}
+		for _, penalty := range penalties {
+			r := &PenaltyRecord{
+				Penalty:     penalty,
+				Play:        p,
+				GameKey:     gameKey,
+				Referee:     referee,
+				RefereeName: refereeName,
+				Offense:     offense,
+				Quarter:     int(p.Quarter),
+				Down:        int(p.Down),
+				Zone:        zone,
+			}
+			if penalty.GSISPlayerID != "" {
+				r.PlayerID = penalty.GSISPlayerID
+				for _, stat := range stats[p.PlayID] {
+					if stat.PlayerID == penalty.GSISPlayerID {
+						r.PlayerName = stat.PlayerName
+						break
+					}
+				}
+			} else if penalty.JerseyNumber != "" {
+				// Declined and offsetting penalties aren't credited, so the player is found by number.
+				if stat, ok := players[penalty.Team+"-"+normalizeJerseyNumber(penalty.JerseyNumber)]; ok {
+					r.PlayerID = stat.PlayerID
+					r.PlayerName = stat.PlayerName
+				}
+			}
+			a.Records = append(a.Records, r)
+		}
+	}
+}
+
+// Filter returns the analytics for the penalties matching the filter. Game counts are unaffected.
+func (a *PenaltyAnalytics) Filter(filter PenaltyFilter) *PenaltyAnalytics {
+	ret := &PenaltyAnalytics{
+		teamGames: a.teamGames,
+		crewGames: a.crewGames,
+	}
+	for _, r := range a.Records {
+		if filter.matches(r) {
+			ret.Records = append(ret.Records, r)
+		}
+	}
+	return ret
+}
+
+// Totals returns the totals for all penalties.
+func (a *PenaltyAnalytics) Totals() PenaltyTotals {
+	var ret PenaltyTotals
+	for _, r := range a.Records {
+		ret.add(r)
+	}
+	return ret
+}
+
+// Teams returns the totals for each penalized team, ordered by club code.
+func (a *PenaltyAnalytics) Teams() []*TeamPenaltyTotals {
+	teams := map[string]*TeamPenaltyTotals{}
+	var ret []*TeamPenaltyTotals
+	for _, r := range a.Records {
+		t, ok := teams[r.Penalty.Team]
+		if !ok {
+			t = &TeamPenaltyTotals{
+				ClubCode: r.Penalty.Team,
+				Games:    a.teamGames[r.Penalty.Team],
+			}
+			teams[r.Penalty.Team] = t
+			ret = append(ret, t)
+		}
+		t.add(r)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ClubCode < ret[j].ClubCode
+	})
+	return ret
+}
+
+// Players returns the totals for each penalized player, ordered by most penalties. Penalties on a
+// team rather than a player are excluded.
+func (a *PenaltyAnalytics) Players() []*PlayerPenaltyTotals {
+	players := map[string]*PlayerPenaltyTotals{}
+	var ret []*PlayerPenaltyTotals
+	for _, r := range a.Records {
+		key := r.PlayerKey()
+		if key == "" {
+			continue
+		}
+		p, ok := players[key]
+		if !ok {
+			p = &PlayerPenaltyTotals{
+				ClubCode:     r.Penalty.Team,
+				JerseyNumber: r.Penalty.JerseyNumber,
+			}
+			players[key] = p
+			ret = append(ret, p)
+		}
+		if p.PlayerName == "" {
+			p.PlayerID = r.PlayerID
+			p.PlayerName = r.PlayerName
+		}
+		p.add(r)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Penalties != ret[j].Penalties {
+			return ret[i].Penalties > ret[j].Penalties
+		} else if ret[i].ClubCode != ret[j].ClubCode {
+			return ret[i].ClubCode < ret[j].ClubCode
+		}
+		return ret[i].JerseyNumber < ret[j].JerseyNumber
+	})
+	return ret
+}
+
+// Crews returns the totals for each officiating crew, identified by its referee's name and jersey
+// number and ordered by name. Games without game attributes are excluded.
+func (a *PenaltyAnalytics) Crews() []*CrewPenaltyTotals {
+	crews := map[string]*CrewPenaltyTotals{}
+	var ret []*CrewPenaltyTotals
+	for _, r := range a.Records {
+		if r.Referee == "" {
+			continue
+		}
+		c, ok := crews[r.Referee]
+		if !ok {
+			c = &CrewPenaltyTotals{
+				Referee:     r.Referee,
+				RefereeName: r.RefereeName,
+				Games:       a.crewGames[r.Referee],
+			}
+			crews[r.Referee] = c
+			ret = append(ret, c)
+		}
+		c.add(r)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].RefereeName != ret[j].RefereeName {
+			return ret[i].RefereeName < ret[j].RefereeName
+		}
+		return ret[i].Referee < ret[j].Referee
+	})
+	return ret
+}
@@ -0,0 +1,121 @@
+package gsis
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldZoneForDistance(t *testing.T) {
+	assert.Equal(t, FieldZoneBackedUp, FieldZoneForDistance(1))
+	assert.Equal(t, FieldZoneOwnTerritory, FieldZoneForDistance(20))
+	assert.Equal(t, FieldZoneOpponentTerritory, FieldZoneForDistance(50))
+	assert.Equal(t, FieldZoneRedZone, FieldZoneForDistance(80))
+}
+
+func TestPenaltyAnalytics(t *testing.T) {
+	buf, err := ioutil.ReadFile("testdata/games/2020010400/GSISGameStats.xml")
+	require.NoError(t, err)
+	var stats StatFile
+	require.NoError(t, xml.Unmarshal(buf, &stats))
+
+	a := NewPenaltyAnalytics()
+	a.AddStatFile(&stats)
+
+	totals := a.Totals()
+	assert.Equal(t, 14, totals.Penalties)
+	assert.Equal(t, 11, totals.Accepted)
+	assert.Equal(t, 1, totals.Declined)
+	assert.Equal(t, 2, totals.Offsetting)
+	assert.Equal(t, 84, totals.Yards)
+	assert.Equal(t, 4, totals.FoulCodes[FoulCodeFalseStart])
+
+	teams := a.Teams()
+	require.Len(t, teams, 2)
+	assert.Equal(t, "BUF", teams[0].ClubCode)
+	assert.Equal(t, 1, teams[0].Games)
+	assert.Equal(t, 9, teams[0].Penalties)
+	assert.Equal(t, 64, teams[0].Yards)
+	assert.Equal(t, "HST", teams[1].ClubCode)
+	assert.Equal(t, 5, teams[1].Penalties)
+
+	crews := a.Crews()
+	require.Len(t, crews, 1)
+	assert.Equal(t, "tony corrente (99)", crews[0].Referee)
+	assert.Equal(t, "Tony Corrente", crews[0].RefereeName)
+	assert.Equal(t, 1, crews[0].Games)
+	assert.Equal(t, 14, crews[0].Penalties)
+
+	players := a.Players()
+	require.NotEmpty(t, players)
+	assert.Equal(t, "00-0035238", players[0].PlayerID)
+	assert.Equal(t, "C.Ford", players[0].PlayerName)
+	assert.Equal(t, 2, players[0].Penalties)
+	assert.Equal(t, 25, players[0].Yards)
+
+	fourthQuarterThirdDowns := a.Filter(PenaltyFilter{
+		Quarters: []int{4},
+		Downs:    []int{3},
+	})
+	totals = fourthQuarterThirdDowns.Totals()
+	assert.Equal(t, 2, totals.Penalties)
+	assert.Equal(t, map[FoulCode]int{FoulCodeIntentionalGrounding: 1, FoulCodeIllegalTouchPass: 1}, totals.FoulCodes)
+	assert.Equal(t, 1, fourthQuarterThirdDowns.Teams()[0].Games)
+
+	for _, r := range a.Filter(PenaltyFilter{Zones: []FieldZone{FieldZoneRedZone}}).Records {
+		distance, ok := r.Play.YardLine.Distance(r.Offense)
+		require.True(t, ok)
+		assert.True(t, distance >= 80)
+	}
+}
+
+func TestPenaltyAnalytics_Games(t *testing.T) {
+	a := NewPenaltyAnalytics()
+	games := 0
+	clubCodes := map[string]bool{}
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		a.AddStatFile(stats)
+		games++
+		if h := stats.CumeStatHeader; h != nil {
+			clubCodes[h.HomeClubCode] = true
+			clubCodes[h.VisitorClubCode] = true
+		}
+	})
+	require.NotZero(t, games)
+
+	for _, r := range a.Records {
+		assert.NotEmpty(t, r.Penalty.FoulCode, r.Play.PlayDescription)
+	}
+	for _, team := range a.Teams() {
+		assert.True(t, clubCodes[team.ClubCode], team.ClubCode)
+	}
+
+	// Every penalty belongs to exactly one team, and to one crew if the referee is known.
+	totals := a.Totals()
+	assert.Equal(t, totals.Penalties, totals.Accepted+totals.Declined+totals.Offsetting)
+	teamPenalties, crewPenalties, crewGames, refereePenalties := 0, 0, 0, 0
+	for _, r := range a.Records {
+		if r.Referee != "" {
+			refereePenalties++
+		}
+	}
+	for _, team := range a.Teams() {
+		teamPenalties += team.Penalties
+	}
+	for _, crew := range a.Crews() {
+		crewPenalties += crew.Penalties
+		crewGames += crew.Games
+	}
+	assert.Equal(t, totals.Penalties, teamPenalties)
+	assert.Equal(t, refereePenalties, crewPenalties)
+	assert.True(t, crewGames <= games)
+
+	zones := 0
+	for _, zone := range []FieldZone{FieldZoneBackedUp, FieldZoneOwnTerritory, FieldZoneOpponentTerritory, FieldZoneRedZone} {
+		zones += a.Filter(PenaltyFilter{Zones: []FieldZone{zone}}).Totals().Penalties
+	}
+	assert.Equal(t, totals.Penalties, zones)
+}
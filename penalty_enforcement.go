@@ -0,0 +1,199 @@
+package gsis
+
+// The offense's position at a snap.
+type DownAndDistance struct {
+	Offense string
+	Defense string
+
+	Down      int
+	YardsToGo int
+
+	// The line of scrimmage.
+	Spot *YardLine
+}
+
+// The result of enforcing a play's penalties.
+type PenaltyEnforcement struct {
+	// The situation for the next snap.
+	After DownAndDistance
+
+	// The net yards the penalties moved the ball towards the defense's goal line, negative for
+	// fouls by the offense.
+	Yards int
+
+	HalfTheDistance    bool
+	AutomaticFirstDown bool
+	LossOfDown         bool
+
+	// True if offsetting fouls cancelled each other out.
+	Offsetting bool
+
+	// True if a penalty was enforced as a dead ball foul, so the down counts.
+	DeadBall bool
+
+	// True if the offense fouled in its own end zone, giving the defense a safety. The next snap is
+	// then the offense's free kick from its own 20.
+	Safety bool
+
+	// The penalties that were enforced, in order.
+	Enforced []PenaltyInfo
+}
+
+// EnforcePenalties computes the situation for the next snap after a play with penalties, given
+// the situation at this one. Declined penalties are ignored, and offsetting penalties cancel out.
+// Accepted penalties are enforced in order, the first from the spot given in the description or
+// the line of scrimmage if there isn't one. The down is replayed unless a penalty results in a
+// first down, carries a loss of down, or is a dead ball foul. Fouls that are normally enforced from
+// the dead ball spot, such as unnecessary roughness, are treated as dead ball fouls when they don't
+// nullify the play or any of its stats.
+//
+// The yardage is taken from the penalty stat or the description when they have it, since they
+// already account for things like half the distance to the goal. Otherwise the yardage from the
+// foul's rule is used.
+//
+// A foul by the offense in its own end zone is a safety, after which the offense free kicks from its
+// own 20, and a penalty on the defense never moves the ball past its 1 yard line.
+//
+// It returns false if there are no accepted or offsetting penalties, since the next snap then
+// depends on the result of the play, or if the penalties can't be enforced, e.g. because they're
+// enforced on the succeeding kickoff.
+func EnforcePenalties(before DownAndDistance, penalties []PenaltyInfo) (*PenaltyEnforcement, bool) {
+	if before.Spot == nil {
+		return nil, false
+	}
+	distance, ok := before.Spot.Distance(before.Offense)
+	if !ok {
+		return nil, false
+	}
+	lineToGain := distance + before.YardsToGo
+
+	ret := &PenaltyEnforcement{}
+	replayed := false
+	for _, penalty := range penalties {
+		switch penalty.Status {
+		case PenaltyStatusOffsetting:
+			ret.Offsetting = true
+			replayed = replayed || penalty.PlayNullified || penalty.EnforcementSpot == PenaltyEnforcementSpotPrevious
+			continue
+		case PenaltyStatusDeclined:
+			continue
+		}
+
+		rule := penalty.FoulCode.Rule()
+		if rule == nil || penalty.Team == "" {
+			return nil, false
+		}
+		byOffense := CommonTeamAbbreviation(penalty.Team) == CommonTeamAbbreviation(before.Offense)
+
+		// Fouls that are normally enforced from the dead ball spot happened after the play unless
+		// they wiped out some of its stats.
+		spot := penalty.EnforcementSpot
+		if spot == PenaltyEnforcementSpotOther && rule.Spot == FoulSpotTypeDeadBall && len(penalty.NullifiedStats) == 0 {
+			spot = PenaltyEnforcementSpotDeadBall
+		}
+		basis := distance
+		switch spot {
+		case PenaltyEnforcementSpotSucceeding:
+			return nil, false
+		case PenaltyEnforcementSpotDeadBall:
+			ret.DeadBall = true
+		}
+		// Later penalties are enforced from wherever the earlier ones left the ball.
+		if penalty.EnforcedAt != nil && len(ret.Enforced) == 0 {
+			if d, ok := penalty.EnforcedAt.Distance(before.Offense); ok {
+				basis = d
+			}
+		}
+
+		// A foul by the offense in its own end zone is a safety, and nothing after it matters.
+		if byOffense && basis <= 0 {
+			ret.Safety = true
+			ret.Enforced = append(ret.Enforced, penalty)
+			break
+		}
+
+		// The distance to the goal line the ball is moving towards.
+		toGoal := 100 - basis
+		if byOffense {
+			toGoal = basis
+		}
+
+		nominal := rule.Yards
+		if !byOffense && rule.DefenseYards != 0 {
+			nominal = rule.DefenseYards
+		}
+		yards := penalty.Yards
+		if yards == 0 {
+			yards = penalty.Distance
+		}
+		if yards == 0 {
+			yards = nominal
+			if yards > toGoal/2 {
+				yards = toGoal / 2
+			}
+		}
+		if yards < nominal && yards <= (toGoal+1)/2 {
+			ret.HalfTheDistance = true
+		}
+		if byOffense {
+			yards = -yards
+		}
+
+		distance = basis + yards
+		if distance >= 100 {
+			// A penalty can't move the ball into the end zone, so fouls by the defense in or near
+			// its own end zone leave the ball at the 1.
+			distance = 99
+		}
+		ret.Yards += yards
+		if byOffense {
+			ret.LossOfDown = ret.LossOfDown || rule.LossOfDown
+		} else {
+			ret.AutomaticFirstDown = ret.AutomaticFirstDown || rule.AutomaticFirstDown
+		}
+		ret.Enforced = append(ret.Enforced, penalty)
+	}
+	if len(ret.Enforced) == 0 && !replayed {
+		// Offsetting fouls after the play leave the result of the play to be determined.
+		return nil, false
+	}
+
+	if ret.Safety {
+		ret.After = DownAndDistance{
+			Offense: before.Offense,
+			Defense: before.Defense,
+			Spot:    NewYardLine(before.Offense, 20),
+		}
+		return ret, true
+	}
+
+	ret.After = DownAndDistance{
+		Offense: before.Offense,
+		Defense: before.Defense,
+		Spot:    NewYardLineFromDistance(distance, before.Offense, before.Defense),
+		Down:    before.Down,
+	}
+	switch {
+	case ret.AutomaticFirstDown || distance >= lineToGain:
+		ret.After.Down = 1
+		lineToGain = distance + 10
+	case ret.LossOfDown || ret.DeadBall:
+		ret.After.Down++
+	}
+	if ret.After.Down > 4 {
+		// The defense takes over on downs.
+		distance = 100 - distance
+		lineToGain = distance + 10
+		ret.After = DownAndDistance{
+			Offense: before.Defense,
+			Defense: before.Offense,
+			Spot:    NewYardLineFromDistance(distance, before.Defense, before.Offense),
+			Down:    1,
+		}
+	}
+	if lineToGain > 100 {
+		lineToGain = 100
+	}
+	ret.After.YardsToGo = lineToGain - distance
+	return ret, true
+}
@@ -0,0 +1,352 @@
+package gsis
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnforcePenalties(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Before    DownAndDistance
+		Penalties []PenaltyInfo
+		After     DownAndDistance
+		Yards     int
+		Check     func(t *testing.T, e *PenaltyEnforcement)
+	}{
+		"FalseStart": {
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 3, YardsToGo: 2, Spot: NewYardLine("SEA", 40)},
+			Penalties: []PenaltyInfo{{
+				FoulCode:        FoulCodeFalseStart,
+				Status:          PenaltyStatusAccepted,
+				Team:            "SEA",
+				EnforcementSpot: PenaltyEnforcementSpotPrevious,
+				EnforcedAt:      NewYardLine("SEA", 40),
+				Distance:        5,
+			}},
+			After: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 3, YardsToGo: 7, Spot: NewYardLine("SEA", 35)},
+			Yards: -5,
+		},
+		"DefensiveOffsideFirstDown": {
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 3, YardsToGo: 2, Spot: NewYardLine("SF", 40)},
+			Penalties: []PenaltyInfo{{
+				FoulCode:        FoulCodeDefensiveOffside,
+				Status:          PenaltyStatusAccepted,
+				Team:            "SF",
+				EnforcementSpot: PenaltyEnforcementSpotPrevious,
+				Distance:        5,
+			}},
+			After: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 1, YardsToGo: 10, Spot: NewYardLine("SF", 35)},
+			Yards: 5,
+		},
+		"AutomaticFirstDown": {
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 3, YardsToGo: 12, Spot: NewYardLine("SEA", 30)},
+			Penalties: []PenaltyInfo{{
+				FoulCode:        FoulCodeDefensiveHolding,
+				Status:          PenaltyStatusAccepted,
+				Team:            "SF",
+				EnforcementSpot: PenaltyEnforcementSpotPrevious,
+			}},
+			After: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 1, YardsToGo: 10, Spot: NewYardLine("SEA", 35)},
+			Yards: 5,
+			Check: func(t *testing.T, e *PenaltyEnforcement) {
+				assert.True(t, e.AutomaticFirstDown)
+			},
+		},
+		"HalfTheDistance": {
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 2, YardsToGo: 10, Spot: NewYardLine("SEA", 8)},
+			Penalties: []PenaltyInfo{{
+				FoulCode:        FoulCodeOffensiveHolding,
+				Status:          PenaltyStatusAccepted,
+				Team:            "SEA",
+				EnforcementSpot: PenaltyEnforcementSpotPrevious,
+			}},
+			After: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 2, YardsToGo: 14, Spot: NewYardLine("SEA", 4)},
+			Yards: -4,
+			Check: func(t *testing.T, e *PenaltyEnforcement) {
+				assert.True(t, e.HalfTheDistance)
+			},
+		},
+		"GoalToGo": {
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 3, YardsToGo: 8, Spot: NewYardLine("SF", 8)},
+			Penalties: []PenaltyInfo{{
+				FoulCode:        FoulCodeDefensivePassInterference,
+				Status:          PenaltyStatusAccepted,
+				Team:            "SF",
+				EnforcementSpot: PenaltyEnforcementSpotPrevious,
+				Distance:        7,
+			}},
+			After: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 1, YardsToGo: 1, Spot: NewYardLine("SF", 1)},
+			Yards: 7,
+		},
+		"LossOfDown": {
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 2, YardsToGo: 10, Spot: NewYardLine("SEA", 30)},
+			Penalties: []PenaltyInfo{{
+				FoulCode:        FoulCodeIntentionalGrounding,
+				Status:          PenaltyStatusAccepted,
+				Team:            "SEA",
+				EnforcementSpot: PenaltyEnforcementSpotOther,
+				EnforcedAt:      NewYardLine("SEA", 30),
+				Distance:        10,
+			}},
+			After: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 3, YardsToGo: 20, Spot: NewYardLine("SEA", 20)},
+			Yards: -10,
+			Check: func(t *testing.T, e *PenaltyEnforcement) {
+				assert.True(t, e.LossOfDown)
+			},
+		},
+		"SpotFoul": {
+			// Holding during a run is enforced from the spot of the foul and the down is replayed.
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 2, YardsToGo: 6, Spot: NewYardLine("SEA", 29)},
+			Penalties: []PenaltyInfo{{
+				FoulCode:        FoulCodeOffensiveHolding,
+				Status:          PenaltyStatusAccepted,
+				Team:            "SEA",
+				EnforcementSpot: PenaltyEnforcementSpotOther,
+				EnforcedAt:      NewYardLine("SEA", 31),
+				Distance:        10,
+			}},
+			After: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 2, YardsToGo: 14, Spot: NewYardLine("SEA", 21)},
+			Yards: -10,
+		},
+		"DeadBall": {
+			// Unnecessary roughness after a run is enforced from the end of the run and the down
+			// counts.
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 3, YardsToGo: 6, Spot: NewYardLine("SF", 33)},
+			Penalties: []PenaltyInfo{{
+				FoulCode:        FoulCodeUnnecessaryRoughness,
+				Status:          PenaltyStatusAccepted,
+				Team:            "SEA",
+				EnforcementSpot: PenaltyEnforcementSpotOther,
+				EnforcedAt:      NewYardLine("SF", 32),
+				Distance:        15,
+			}},
+			After: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 4, YardsToGo: 20, Spot: NewYardLine("SF", 47)},
+			Yards: -15,
+			Check: func(t *testing.T, e *PenaltyEnforcement) {
+				assert.True(t, e.DeadBall)
+			},
+		},
+		"LiveBallPersonalFoul": {
+			// Unnecessary roughness during a run that wiped out the rest of it is a spot foul, so the
+			// down is replayed.
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 3, YardsToGo: 6, Spot: NewYardLine("SF", 33)},
+			Penalties: []PenaltyInfo{{
+				FoulCode:        FoulCodeUnnecessaryRoughness,
+				Status:          PenaltyStatusAccepted,
+				Team:            "SEA",
+				EnforcementSpot: PenaltyEnforcementSpotOther,
+				EnforcedAt:      NewYardLine("SF", 32),
+				Distance:        15,
+				NullifiedStats:  []StatFilePlayStat{{StatID: StatIDRushingYards}},
+			}},
+			After: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 3, YardsToGo: 20, Spot: NewYardLine("SF", 47)},
+			Yards: -15,
+			Check: func(t *testing.T, e *PenaltyEnforcement) {
+				assert.False(t, e.DeadBall)
+			},
+		},
+		"TurnoverOnDowns": {
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 4, YardsToGo: 2, Spot: NewYardLine("SF", 30)},
+			Penalties: []PenaltyInfo{{
+				FoulCode:        FoulCodeIllegalForwardPass,
+				Status:          PenaltyStatusAccepted,
+				Team:            "SEA",
+				EnforcementSpot: PenaltyEnforcementSpotOther,
+				EnforcedAt:      NewYardLine("SF", 25),
+				Distance:        5,
+			}},
+			After: DownAndDistance{Offense: "SF", Defense: "SEA", Down: 1, YardsToGo: 10, Spot: NewYardLine("SF", 30)},
+			Yards: -5,
+		},
+		"Safety": {
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 2, YardsToGo: 10, Spot: NewYardLine("SEA", 6)},
+			Penalties: []PenaltyInfo{{
+				FoulCode:        FoulCodeIntentionalGrounding,
+				Status:          PenaltyStatusAccepted,
+				Team:            "SEA",
+				EnforcementSpot: PenaltyEnforcementSpotOther,
+				EnforcedAt:      NewYardLine("SEA", 0),
+				Distance:        6,
+			}},
+			After: DownAndDistance{Offense: "SEA", Defense: "SF", Spot: NewYardLine("SEA", 20)},
+			Check: func(t *testing.T, e *PenaltyEnforcement) {
+				assert.True(t, e.Safety)
+				assert.Len(t, e.Enforced, 1)
+			},
+		},
+		"SafetyNoPlay": {
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 2, YardsToGo: 9, Spot: NewYardLine("SEA", 5)},
+			Penalties: []PenaltyInfo{{
+				FoulCode:        FoulCodeOffensiveHolding,
+				Status:          PenaltyStatusAccepted,
+				Team:            "SEA",
+				EnforcementSpot: PenaltyEnforcementSpotPrevious,
+				EnforcedAt:      NewYardLine("SEA", 0),
+				Distance:        5,
+			}},
+			After: DownAndDistance{Offense: "SEA", Defense: "SF", Spot: NewYardLine("SEA", 20)},
+			Check: func(t *testing.T, e *PenaltyEnforcement) {
+				assert.True(t, e.Safety)
+			},
+		},
+		"DefenseInEndZone": {
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 3, YardsToGo: 4, Spot: NewYardLine("SF", 4)},
+			Penalties: []PenaltyInfo{{
+				FoulCode:        FoulCodeDefensiveHolding,
+				Status:          PenaltyStatusAccepted,
+				Team:            "SF",
+				EnforcementSpot: PenaltyEnforcementSpotPrevious,
+				EnforcedAt:      NewYardLine("SF", 0),
+			}},
+			After: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 1, YardsToGo: 1, Spot: NewYardLine("SF", 1)},
+			Yards: 0,
+			Check: func(t *testing.T, e *PenaltyEnforcement) {
+				assert.True(t, e.AutomaticFirstDown)
+			},
+		},
+		"Offsetting": {
+			Before: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 2, YardsToGo: 7, Spot: NewYardLine("SEA", 45)},
+			Penalties: []PenaltyInfo{
+				{
+					FoulCode:        FoulCodeDefensivePassInterference,
+					Status:          PenaltyStatusOffsetting,
+					Team:            "SF",
+					EnforcementSpot: PenaltyEnforcementSpotPrevious,
+					EnforcedAt:      NewYardLine("SEA", 45),
+				},
+				{
+					FoulCode: FoulCodeOffensiveHolding,
+					Status:   PenaltyStatusOffsetting,
+					Team:     "SEA",
+				},
+			},
+			After: DownAndDistance{Offense: "SEA", Defense: "SF", Down: 2, YardsToGo: 7, Spot: NewYardLine("SEA", 45)},
+			Check: func(t *testing.T, e *PenaltyEnforcement) {
+				assert.True(t, e.Offsetting)
+				assert.Empty(t, e.Enforced)
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e, ok := EnforcePenalties(tc.Before, tc.Penalties)
+			require.True(t, ok)
+			assert.Equal(t, tc.After.Offense, e.After.Offense)
+			assert.Equal(t, tc.After.Defense, e.After.Defense)
+			assert.Equal(t, tc.After.Down, e.After.Down)
+			assert.Equal(t, tc.After.YardsToGo, e.After.YardsToGo)
+			assert.Equal(t, tc.After.Spot.String(), e.After.Spot.String())
+			assert.Equal(t, tc.Yards, e.Yards)
+			if tc.Check != nil {
+				tc.Check(t, e)
+			}
+		})
+	}
+}
+
+func TestEnforcePenalties_NotEnforceable(t *testing.T) {
+	before := DownAndDistance{Offense: "SEA", Defense: "SF", Down: 1, YardsToGo: 10, Spot: NewYardLine("SEA", 25)}
+
+	// Declined penalties leave the result to the play.
+	_, ok := EnforcePenalties(before, []PenaltyInfo{{
+		FoulCode: FoulCodeOffensiveHolding,
+		Status:   PenaltyStatusDeclined,
+		Team:     "SEA",
+	}})
+	assert.False(t, ok)
+
+	_, ok = EnforcePenalties(before, []PenaltyInfo{{
+		FoulCode:        FoulCodeUnnecessaryRoughness,
+		Status:          PenaltyStatusAccepted,
+		Team:            "SF",
+		EnforcementSpot: PenaltyEnforcementSpotSucceeding,
+		Distance:        15,
+	}})
+	assert.False(t, ok)
+
+	_, ok = EnforcePenalties(DownAndDistance{Offense: "SEA", Defense: "SF", Down: 1, YardsToGo: 10}, []PenaltyInfo{{
+		FoulCode:        FoulCodeFalseStart,
+		Status:          PenaltyStatusAccepted,
+		Team:            "SEA",
+		EnforcementSpot: PenaltyEnforcementSpotPrevious,
+		Distance:        5,
+	}})
+	assert.False(t, ok)
+}
+
+// Plays whose penalties were scored differently from the rules, by game and play ID.
+var inconsistentPenaltyEnforcements = map[string]StringInt{
+	// An illegal forward pass from behind the line of scrimmage, which doesn't carry a loss of down.
+	"2018091607": 3508,
+}
+
+// Enforces the penalties on every play from scrimmage and compares the result to the next play.
+func TestEnforcePenalties_Games(t *testing.T) {
+	total, safeties := 0, 0
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		require.NotNil(t, stats.CumeStatHeader)
+
+		turnovers := map[StringInt]bool{}
+		for _, stat := range stats.PlayStat {
+			if stat.StatID.IsTurnover() || stat.StatID == StatIDPuntingYards {
+				turnovers[stat.PlayID] = true
+			}
+		}
+
+		var plays []*StatFilePlay
+		for _, p := range stats.ActualPlays() {
+			if p.PlayType != PlayTypeTimeout {
+				plays = append(plays, p)
+			}
+		}
+		for i, p := range plays[:len(plays)-1] {
+			next := plays[i+1]
+			if p.PlayType != PlayTypePlayFromScrimmage || p.Down == 0 || turnovers[p.PlayID] || inconsistentPenaltyEnforcements[name] == p.PlayID {
+				continue
+			}
+			// Reviews describe both rulings.
+			if strings.Contains(p.PlayDescription, "REVERSED") {
+				continue
+			}
+
+			defense := stats.CumeStatHeader.HomeClubCode
+			if p.PossessionTeam == defense {
+				defense = stats.CumeStatHeader.VisitorClubCode
+			}
+			e, ok := EnforcePenalties(DownAndDistance{
+				Offense:   p.PossessionTeam,
+				Defense:   defense,
+				Down:      int(p.Down),
+				YardsToGo: int(p.YardsToGo),
+				Spot:      &p.YardLine,
+			}, p.Penalties(stats.PlayStat, stats.PlayStatNullified))
+			if !ok {
+				continue
+			}
+
+			if e.Safety {
+				safeties++
+				if assert.Equal(t, PlayTypeFreeKick, int(next.PlayType), "play %v: %v", p.PlayID, p.PlayDescription) {
+					assert.Equal(t, e.After.Spot.Normalized(), next.YardLine.Normalized(), "play %v: %v", p.PlayID, p.PlayDescription)
+				}
+				continue
+			}
+			if next.PlayType != PlayTypePlayFromScrimmage || next.Down == 0 || next.PossessionTeam != p.PossessionTeam {
+				continue
+			}
+
+			total++
+			got, _ := e.After.Spot.Distance(p.PossessionTeam)
+			want, _ := next.YardLine.Distance(p.PossessionTeam)
+			assert.Equal(t,
+				fmt.Sprintf("%v & %v at %v", next.Down, next.YardsToGo, want),
+				fmt.Sprintf("%v & %v at %v", e.After.Down, e.After.YardsToGo, got),
+				"play %v: %v", p.PlayID, p.PlayDescription)
+		}
+	})
+	require.NotZero(t, total)
+	require.NotZero(t, safeties)
+}
@@ -0,0 +1,89 @@
+package gsis
+
+import (
+	"regexp"
+	"sort"
+)
+
+// PenaltyReport summarizes penalty parsing problems across many games. It's intended for finding
+// phrases the parser doesn't understand yet.
+type PenaltyReport struct {
+	// The number of plays with penalties, and the number of penalties found on them.
+	Plays     int
+	Penalties int
+
+	Warnings map[PenaltyWarning]int
+
+	// Unparsed fragments with digits replaced by "#", so that e.g. yardages are grouped together.
+	Unparsed map[string]*PenaltyReportPhrase
+}
+
+type PenaltyReportPhrase struct {
+	Phrase string
+	Count  int
+
+	// The description of the first play the phrase was seen on.
+	Example string
+}
+
+func NewPenaltyReport() *PenaltyReport {
+	return &PenaltyReport{
+		Warnings: map[PenaltyWarning]int{},
+		Unparsed: map[string]*PenaltyReportPhrase{},
+	}
+}
+
+var penaltyReportDigitsRegexp = regexp.MustCompile(`\d+`)
+
+// AddPlayDescription adds a play's penalties to the report.
+func (r *PenaltyReport) AddPlayDescription(description string) {
+	diagnostics := ParsePlayDescriptionPenaltiesWithDiagnostics(description)
+	if len(diagnostics.Penalties) == 0 {
+		return
+	}
+	r.Plays++
+	for _, d := range diagnostics.Penalties {
+		if !d.Discarded {
+			r.Penalties++
+		}
+		for _, w := range d.Warnings {
+			r.Warnings[w]++
+		}
+	}
+	for _, fragment := range diagnostics.Unparsed {
+		phrase := penaltyReportDigitsRegexp.ReplaceAllString(fragment, "#")
+		if p, ok := r.Unparsed[phrase]; ok {
+			p.Count++
+		} else {
+			r.Unparsed[phrase] = &PenaltyReportPhrase{
+				Phrase:  phrase,
+				Count:   1,
+				Example: description,
+			}
+		}
+	}
+}
+
+// AddStatFile adds the penalties of each of the file's plays that wasn't deleted.
+func (r *PenaltyReport) AddStatFile(f *StatFile) {
+	for _, p := range f.Play {
+		if p.PlayDeleted == 0 {
+			r.AddPlayDescription(p.PlayDescription)
+		}
+	}
+}
+
+// UnparsedPhrases returns the unparsed phrases, most common first.
+func (r *PenaltyReport) UnparsedPhrases() []*PenaltyReportPhrase {
+	ret := make([]*PenaltyReportPhrase, 0, len(r.Unparsed))
+	for _, p := range r.Unparsed {
+		ret = append(ret, p)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		return ret[i].Phrase < ret[j].Phrase
+	})
+	return ret
+}
@@ -0,0 +1,43 @@
+package gsis
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPenaltyReport(t *testing.T) {
+	r := NewPenaltyReport()
+	r.AddPlayDescription("(12:12) (Shotgun) PENALTY on LA-76-R.Havenstein, False Start, 5 yards, enforced at LA 25 - No Play.")
+	r.AddPlayDescription("(15:00) J.Myers kicks 65 yards from SEA 35 to end zone, Touchback.")
+	r.AddPlayDescription("(:15) M.Haack punts 63 yards to end zone, Center-J.Denney, Touchback.\nPENALTY on OAK-J.Richard, Illegal Block Above the Waist, 18 yards, enforced at OAK 20. Penalty was 2 yards, enforced from the OAK 4 yard line.")
+	r.AddPlayDescription("(5:00) M.Haack punts 50 yards to end zone, Center-J.Denney, Touchback.\nPENALTY on OAK-J.Richard, Illegal Block Above the Waist, 10 yards, enforced at OAK 20. Penalty was 5 yards, enforced from the OAK 10 yard line.")
+
+	assert.Equal(t, 3, r.Plays)
+	assert.Equal(t, 3, r.Penalties)
+	assert.Equal(t, 2, r.Warnings[PenaltyWarningDiscardedIncomplete])
+
+	phrases := r.UnparsedPhrases()
+	require.Len(t, phrases, 2)
+	// Ties are broken alphabetically.
+	assert.Equal(t, "enforced from the oak # yard line", phrases[0].Phrase)
+	assert.Equal(t, "penalty was # yards", phrases[1].Phrase)
+	assert.Equal(t, 2, phrases[1].Count)
+	assert.Contains(t, phrases[1].Example, "Penalty was 2 yards")
+}
+
+func TestPenaltyReport_AddStatFile(t *testing.T) {
+	buf, err := ioutil.ReadFile("testdata/signalr-stats.json")
+	require.NoError(t, err)
+	var stats StatFile
+	require.NoError(t, json.Unmarshal(buf, &stats))
+
+	r := NewPenaltyReport()
+	r.AddStatFile(&stats)
+	assert.NotZero(t, r.Plays)
+	assert.True(t, r.Penalties >= r.Plays)
+	assert.Zero(t, r.Warnings[PenaltyWarningUnknownFoul])
+}
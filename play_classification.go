@@ -0,0 +1,148 @@
+package gsis
+
+type PlayCategory string
+
+const (
+	PlayCategoryUnknown          PlayCategory = ""
+	PlayCategoryDesignedRun      PlayCategory = "DESIGNED_RUN"
+	PlayCategoryDropbackPass     PlayCategory = "DROPBACK_PASS"
+	PlayCategorySack             PlayCategory = "SACK"
+	PlayCategoryScramble         PlayCategory = "SCRAMBLE"
+	PlayCategoryKneel            PlayCategory = "KNEEL"
+	PlayCategorySpike            PlayCategory = "SPIKE"
+	PlayCategoryPunt             PlayCategory = "PUNT"
+	PlayCategoryFieldGoal        PlayCategory = "FIELD_GOAL"
+	PlayCategoryExtraPoint       PlayCategory = "EXTRA_POINT"
+	PlayCategoryTwoPoint         PlayCategory = "TWO_POINT"
+	PlayCategoryKickoff          PlayCategory = "KICKOFF"
+	PlayCategoryOnsideKick       PlayCategory = "ONSIDE_KICK"
+	PlayCategoryFakePunt         PlayCategory = "FAKE_PUNT"
+	PlayCategoryFakeFieldGoal    PlayCategory = "FAKE_FIELD_GOAL"
+	PlayCategoryNoPlay           PlayCategory = "NO_PLAY"
+	PlayCategoryAbortedSnap      PlayCategory = "ABORTED_SNAP"
+	PlayCategoryTimeout          PlayCategory = "TIMEOUT"
+	PlayCategoryTwoMinuteWarning PlayCategory = "TWO_MINUTE_WARNING"
+)
+
+// Runs of at least this many yards are considered explosive.
+const ExplosiveRunYards = 10
+
+// Passes of at least this many yards are considered explosive.
+const ExplosivePassYards = 20
+
+type PlayClassification struct {
+	Category PlayCategory
+
+	// True if the ball was intercepted or a fumble was lost.
+	Turnover bool
+
+	Touchdown bool
+
+	// True if the offense earned a first down, including by penalty.
+	FirstDown bool
+
+	// True for runs and passes that gained at least ExplosiveRunYards or ExplosivePassYards.
+	Explosive bool
+}
+
+// Classify categorizes the play using its stats and description. The stats may include rows for
+// other plays, which are ignored. Plays that aren't actual plays are left uncategorized.
+func (p *StatFilePlay) Classify(stats []StatFilePlayStat) PlayClassification {
+	var ret PlayClassification
+	if !p.IsActualPlay() {
+		return ret
+	}
+	if p.PlayType == PlayTypeTimeout {
+		if p.PlayDescription == "Two-Minute Warning" {
+			ret.Category = PlayCategoryTwoMinuteWarning
+		} else {
+			ret.Category = PlayCategoryTimeout
+		}
+		return ret
+	}
+
+	has := map[StatID]bool{}
+	rushingYards, passingYards := 0, 0
+	for _, stat := range stats {
+		if stat.PlayID != p.PlayID {
+			continue
+		}
+		has[stat.StatID] = true
+		ret.Turnover = ret.Turnover || stat.StatID.IsTurnover()
+		ret.Touchdown = ret.Touchdown || stat.StatID.IsTouchdown()
+		ret.FirstDown = ret.FirstDown || stat.StatID.IsFirstDown()
+		switch stat.StatID {
+		case StatIDRushingYards, StatIDRushingYardsTD, StatIDRushingYardsNoRush, StatIDRushingYardsTDNoRush:
+			rushingYards += stat.Yards.Int()
+		case StatIDPassingYards, StatIDPassingYardsTD, StatIDPassingYardsNoPass, StatIDPassingYardsTDNoPass:
+			passingYards += stat.Yards.Int()
+		}
+	}
+	hasAny := func(ids ...StatID) bool {
+		for _, id := range ids {
+			if has[id] {
+				return true
+			}
+		}
+		return false
+	}
+
+	info := ParsePlayDescription(p.PlayDescription)
+	if len(has) == 0 {
+		// Stats may not have been recorded yet.
+		ret.Touchdown = info.Touchdown
+	}
+	formation := map[string]bool{}
+	for _, f := range info.Formations {
+		formation[f] = true
+	}
+
+	isPass := hasAny(StatIDPassIncomplete, StatIDPassingYards, StatIDPassingYardsTD, StatIDInterceptionPasser) || info.Type == PlayDescriptionTypePass
+	isRun := hasAny(StatIDRushingYards, StatIDRushingYardsTD) || info.Type == PlayDescriptionTypeRun
+	isSack := has[StatIDSackYards] || info.Type == PlayDescriptionTypeSack
+
+	switch {
+	case info.NoPlay || info.Type == PlayDescriptionTypeNoPlay:
+		ret.Category = PlayCategoryNoPlay
+	case p.PlayType == PlayTypeTry:
+		if info.TwoPointAttempt || hasAny(StatID2PointRushGood, StatID2PointRushFailed, StatID2PointPassGood, StatID2PointPassFailed, StatID2PointPassReceptionGood, StatID2PointPassReceptionFailed) {
+			ret.Category = PlayCategoryTwoPoint
+		} else {
+			ret.Category = PlayCategoryExtraPoint
+		}
+	case p.PlayType == PlayTypeFreeKick || info.Type == PlayDescriptionTypeKickoff:
+		if info.Onside || formation["Onside Kick formation"] {
+			ret.Category = PlayCategoryOnsideKick
+		} else {
+			ret.Category = PlayCategoryKickoff
+		}
+	case hasAny(StatIDPuntingYards, StatIDPuntBlocked) || info.Type == PlayDescriptionTypePunt:
+		ret.Category = PlayCategoryPunt
+	case hasAny(StatIDFieldGoalYards, StatIDFieldGoalMissedYards, StatIDFieldGoalBlockedOffense) || info.Type == PlayDescriptionTypeFieldGoal:
+		ret.Category = PlayCategoryFieldGoal
+	case info.Type == PlayDescriptionTypeAbortedSnap:
+		ret.Category = PlayCategoryAbortedSnap
+	case formation["Punt formation"] && (isPass || isRun || isSack):
+		ret.Category = PlayCategoryFakePunt
+	case formation["Field Goal formation"] && (isPass || isRun || isSack):
+		ret.Category = PlayCategoryFakeFieldGoal
+	case info.Type == PlayDescriptionTypeSpike:
+		ret.Category = PlayCategorySpike
+	case info.Type == PlayDescriptionTypeKneel:
+		ret.Category = PlayCategoryKneel
+	case isSack:
+		ret.Category = PlayCategorySack
+	case isPass:
+		ret.Category = PlayCategoryDropbackPass
+	case isRun && info.Scramble:
+		ret.Category = PlayCategoryScramble
+	case isRun:
+		ret.Category = PlayCategoryDesignedRun
+	}
+
+	switch ret.Category {
+	case PlayCategoryDesignedRun, PlayCategoryScramble, PlayCategoryDropbackPass, PlayCategoryFakePunt, PlayCategoryFakeFieldGoal:
+		ret.Explosive = rushingYards >= ExplosiveRunYards || passingYards >= ExplosivePassYards
+	}
+	return ret
+}
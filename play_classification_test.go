@@ -0,0 +1,75 @@
+package gsis
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatFilePlay_Classify(t *testing.T) {
+	games := map[string]*StatFile{}
+	for _, tc := range []struct {
+		Name     string
+		Game     string
+		PlayID   int
+		Expected PlayClassification
+	}{
+		{"DesignedRun", "2018090900", 337, PlayClassification{Category: PlayCategoryDesignedRun, FirstDown: true}},
+		{"ExplosiveRun", "2018090900", 2525, PlayClassification{Category: PlayCategoryDesignedRun, FirstDown: true, Explosive: true}},
+		{"RushingTouchdown", "2018090900", 358, PlayClassification{Category: PlayCategoryDesignedRun, Touchdown: true, FirstDown: true}},
+		{"LostFumble", "2018090900", 1474, PlayClassification{Category: PlayCategoryDesignedRun, Turnover: true}},
+		{"ExplosivePass", "2018090900", 71, PlayClassification{Category: PlayCategoryDropbackPass, FirstDown: true, Explosive: true}},
+		{"IncompletePass", "2018090900", 210, PlayClassification{Category: PlayCategoryDropbackPass}},
+		{"Interception", "2018090900", 1869, PlayClassification{Category: PlayCategoryDropbackPass, Turnover: true}},
+		{"Sack", "2018090900", 466, PlayClassification{Category: PlayCategorySack}},
+		{"Scramble", "2018090900", 3104, PlayClassification{Category: PlayCategoryScramble, FirstDown: true, Explosive: true}},
+		{"Kneel", "2019101306", 2237, PlayClassification{Category: PlayCategoryKneel}},
+		{"Spike", "2018091607", 3845, PlayClassification{Category: PlayCategorySpike}},
+		{"Punt", "2018090900", 485, PlayClassification{Category: PlayCategoryPunt}},
+		{"FieldGoal", "2018090900", 1226, PlayClassification{Category: PlayCategoryFieldGoal}},
+		{"ExtraPoint", "2018090900", 380, PlayClassification{Category: PlayCategoryExtraPoint}},
+		{"TwoPoint", "2018090900", 2402, PlayClassification{Category: PlayCategoryTwoPoint}},
+		{"Kickoff", "2018090900", 36, PlayClassification{Category: PlayCategoryKickoff}},
+		{"OnsideKick", "2018090903", 4162, PlayClassification{Category: PlayCategoryOnsideKick}},
+		{"FakePunt", "2018091607", 329, PlayClassification{Category: PlayCategoryFakePunt, Touchdown: true, FirstDown: true, Explosive: true}},
+		{"FakeFieldGoal", "2019101306", 2205, PlayClassification{Category: PlayCategoryFakeFieldGoal, Turnover: true}},
+		{"NoPlay", "2018090900", 148, PlayClassification{Category: PlayCategoryNoPlay}},
+		{"NoPlayFirstDown", "2018090900", 742, PlayClassification{Category: PlayCategoryNoPlay, FirstDown: true}},
+		{"AbortedSnap", "2018090900", 120, PlayClassification{Category: PlayCategoryAbortedSnap}},
+		{"AbortedSnapInPuntFormation", "2018102105", 1099, PlayClassification{Category: PlayCategoryAbortedSnap}},
+		{"Timeout", "2018090900", 1511, PlayClassification{Category: PlayCategoryTimeout}},
+		{"TwoMinuteWarning", "2018090900", 2182, PlayClassification{Category: PlayCategoryTwoMinuteWarning}},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			stats, ok := games[tc.Game]
+			if !ok {
+				buf, err := ioutil.ReadFile(filepath.Join("testdata/games", tc.Game, "GSISGameStats.xml"))
+				require.NoError(t, err)
+				stats = &StatFile{}
+				require.NoError(t, xml.Unmarshal(buf, stats))
+				games[tc.Game] = stats
+			}
+
+			var play *StatFilePlay
+			for _, p := range stats.Play {
+				if int(p.PlayID) == tc.PlayID {
+					play = p
+				}
+			}
+			require.NotNil(t, play)
+			assert.Equal(t, tc.Expected, play.Classify(stats.PlayStat), play.PlayDescription)
+		})
+	}
+}
+
+func TestStatFilePlay_Classify_Games(t *testing.T) {
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		for _, p := range stats.ActualPlays() {
+			assert.NotEqual(t, PlayCategoryUnknown, p.Classify(stats.PlayStat).Category, p.PlayDescription)
+		}
+	})
+}
@@ -0,0 +1,844 @@
+package gsis
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type PlayDescriptionType string
+
+const (
+	PlayDescriptionTypeUnknown    PlayDescriptionType = ""
+	PlayDescriptionTypeRun        PlayDescriptionType = "RUN"
+	PlayDescriptionTypePass       PlayDescriptionType = "PASS"
+	PlayDescriptionTypeSack       PlayDescriptionType = "SACK"
+	PlayDescriptionTypeKneel      PlayDescriptionType = "KNEEL"
+	PlayDescriptionTypeSpike      PlayDescriptionType = "SPIKE"
+	PlayDescriptionTypeKickoff    PlayDescriptionType = "KICKOFF"
+	PlayDescriptionTypePunt       PlayDescriptionType = "PUNT"
+	PlayDescriptionTypeFieldGoal  PlayDescriptionType = "FIELD_GOAL"
+	PlayDescriptionTypeExtraPoint PlayDescriptionType = "EXTRA_POINT"
+
+	// A fumbled snap or handoff with no other action.
+	PlayDescriptionTypeAbortedSnap PlayDescriptionType = "ABORTED_SNAP"
+
+	// A penalty with no other action, such as a false start.
+	PlayDescriptionTypeNoPlay PlayDescriptionType = "NO_PLAY"
+)
+
+type KickResult string
+
+const (
+	KickResultGood        KickResult = "GOOD"
+	KickResultNoGood      KickResult = "NO_GOOD"
+	KickResultBlocked     KickResult = "BLOCKED"
+	KickResultTouchback   KickResult = "TOUCHBACK"
+	KickResultFairCatch   KickResult = "FAIR_CATCH"
+	KickResultOutOfBounds KickResult = "OUT_OF_BOUNDS"
+	KickResultDowned      KickResult = "DOWNED"
+	KickResultReturned    KickResult = "RETURNED"
+	KickResultMuffed      KickResult = "MUFFED"
+)
+
+type PlayDescriptionCarryKind string
+
+const (
+	PlayDescriptionCarryKindRun                PlayDescriptionCarryKind = "RUN"
+	PlayDescriptionCarryKindReception          PlayDescriptionCarryKind = "RECEPTION"
+	PlayDescriptionCarryKindSack               PlayDescriptionCarryKind = "SACK"
+	PlayDescriptionCarryKindKneel              PlayDescriptionCarryKind = "KNEEL"
+	PlayDescriptionCarryKindReturn             PlayDescriptionCarryKind = "RETURN"
+	PlayDescriptionCarryKindInterceptionReturn PlayDescriptionCarryKind = "INTERCEPTION_RETURN"
+	PlayDescriptionCarryKindFumbleReturn       PlayDescriptionCarryKind = "FUMBLE_RETURN"
+	PlayDescriptionCarryKindLateral            PlayDescriptionCarryKind = "LATERAL"
+)
+
+// A single player's possession of the ball, such as a run, reception, or return.
+type PlayDescriptionCarry struct {
+	Kind PlayDescriptionCarryKind
+
+	// The ball carrier. Names in descriptions with jersey numbers include the number, e.g.
+	// "3-R.Wilson".
+	Player string
+
+	// Where the carry ended, if given.
+	Spot        *YardLine
+	Yards       int
+	OutOfBounds bool
+	Touchdown   bool
+	Safety      bool
+	Tacklers    []string
+}
+
+type PlayDescriptionFumble struct {
+	Player string
+
+	// True for fumbled snaps and botched handoffs.
+	Aborted bool
+
+	// True for muffed kicks and punts.
+	Muffed bool
+
+	ForcedBy string
+	At       *YardLine
+
+	// The player that recovered the fumble. For fumbles recovered by the fumbling player, this is
+	// the same as Player.
+	RecoveredBy     string
+	RecoveredByTeam string
+	RecoveredAt     *YardLine
+	OutOfBoundsAt   *YardLine
+}
+
+type PlayDescriptionInfo struct {
+	Clock GameTime
+
+	// Formation tags such as "Shotgun", "No Huddle", and "Punt formation".
+	Formations []string
+
+	// Players that reported in as eligible receivers.
+	ReportedEligible []string
+
+	DirectSnapTo string
+
+	Type            PlayDescriptionType
+	TwoPointAttempt bool
+
+	// Whether the two-point try succeeded. Only meaningful if TwoPointAttempt is true.
+	TwoPointSucceeded bool
+
+	// True if a penalty wiped out the play.
+	NoPlay bool
+
+	Passer string
+
+	// The intended receiver of a pass. This may be empty for passes that were thrown away.
+	Receiver string
+
+	// "short" or "deep".
+	PassDepth string
+
+	// "left", "middle", or "right".
+	PassLocation string
+
+	Complete        bool
+	PassDefensedBy  []string
+	QuarterbackHits []string
+	InterceptedBy   string
+	InterceptedAt   *YardLine
+
+	// Where the pass was caught, and the yards gained through the air and after the catch. These
+	// are only given for some plays.
+	CaughtAt        *YardLine
+	AirYards        *int
+	YardsAfterCatch *int
+
+	Rusher string
+
+	// "left end", "left tackle", "left guard", "up the middle", "right guard", "right tackle", or
+	// "right end".
+	RunDirection string
+
+	Scramble bool
+
+	Kicker        string
+	KickYards     int
+	KickFrom      *YardLine
+	KickTo        *YardLine
+	KickToEndZone bool
+	Onside        bool
+	KickResult    KickResult
+
+	// Why a field goal or extra point missed, e.g. "Wide Right" or "Hit Left Upright".
+	KickMissReason string
+
+	BlockedBy string
+
+	// Who recovered a blocked kick or an onside kick that nobody possessed.
+	KickRecoveredBy     string
+	KickRecoveredByTeam string
+	KickRecoveredAt     *YardLine
+
+	LongSnapper string
+	Holder      string
+	FairCatchBy string
+	DownedBy    string
+
+	// Every player that carried the ball, in order. For runs and receptions, the first carry is
+	// the rusher or receiver's. Returns, laterals, and advances after turnovers follow.
+	Carries []PlayDescriptionCarry
+
+	Fumbles []PlayDescriptionFumble
+
+	Touchdown bool
+	Safety    bool
+
+	// Players that were injured on the play.
+	Injured []string
+
+	Penalties []PenaltyInfo
+
+	// The replay reviews of the play, if there were any.
+	Reviews []ReplayReview
+
+	// If a replay review reversed the ruling on the field, the description of the play as it was
+	// originally ruled. The rest of the info describes the play after the reversal.
+	Overturned *PlayDescriptionInfo
+
+	// Any sentences or fragments that the parser didn't understand.
+	Unparsed []string
+}
+
+const (
+	playDescriptionJerseyNumberExpr = `\d{1,2}[A-Z]?`
+	playDescriptionPlayerNameExpr   = `[A-Z][A-Za-z]{0,3}\. ?(?:(?:St\.|Van|Vander|Von|Jean|De|Da|La|Le|Du|Mc) )?[A-Z][A-Za-z'\-]*[a-z][A-Za-z'\-]*(?: (?:Jr|Sr)\.?| (?:II|III|IV)\b)?`
+
+	// A player's name, optionally preceded by their jersey number.
+	playDescriptionNameExpr  = `(?:` + playDescriptionJerseyNumberExpr + `-)?` + playDescriptionPlayerNameExpr
+	playDescriptionNamesExpr = playDescriptionNameExpr + `(?:(?:; |, | and )` + playDescriptionNameExpr + `)*`
+	playDescriptionSpotExpr  = `(?:[A-Z]{2,3} )?-?\d{1,2}`
+	playDescriptionTeamExpr  = `[A-Z]{2,3}`
+)
+
+func playDescriptionRegexp(expr string) *regexp.Regexp {
+	expr = strings.NewReplacer(
+		"NAMES", playDescriptionNamesExpr,
+		"NAME", playDescriptionNameExpr,
+		"SPOT", playDescriptionSpotExpr,
+		"TEAM", playDescriptionTeamExpr,
+	).Replace(expr)
+	return regexp.MustCompile("^" + expr)
+}
+
+var (
+	playDescriptionNameListSeparatorRegexp = regexp.MustCompile(`; |, | and `)
+	playDescriptionWordRegexp              = regexp.MustCompile(`^\S+`)
+
+	playDescriptionSeparatorRegexp = regexp.MustCompile(`^[\s.,;]+`)
+	playDescriptionClockRegexp     = regexp.MustCompile(`^\((\d*:\d\d)\)`)
+	playDescriptionFormationRegexp = regexp.MustCompile(`^\(((?:No Huddle|Shotgun|(?:Punt|Field Goal|Kick|Pass|Run|Onside Kick) formation)(?:, (?:No Huddle|Shotgun))*)\)`)
+
+	playDescriptionCarryTailRegexp = playDescriptionRegexp(`(?: \(didn't try to advance\))?(?: (pushed ob|ran ob) at (SPOT)| to (SPOT)|,? dead ball declared at (SPOT)| (?:tackled )?(in End Zone))?(?: for (-?\d+) yards?| for (no gain))?`)
+	playDescriptionScoreRegexp     = playDescriptionRegexp(`,? (TOUCHDOWN NULLIFIED by Penalty|TOUCHDOWN|SAFETY)`)
+	playDescriptionTacklersRegexp  = playDescriptionRegexp(` \((?:sack split by )?(NAMES)\)`)
+	playDescriptionHitsRegexp      = playDescriptionRegexp(` \[(NAMES)\]`)
+
+	playDescriptionReportedEligibleRegexp = playDescriptionRegexp(`(NAMES) reported in as eligible`)
+	playDescriptionDirectSnapRegexp       = playDescriptionRegexp(`Direct snap to (NAME)`)
+	playDescriptionTwoPointRegexp         = playDescriptionRegexp(`TWO-POINT CONVERSION ATTEMPT`)
+	playDescriptionTwoPointResultRegexp   = playDescriptionRegexp(`ATTEMPT (SUCCEEDS|FAILS)`)
+	playDescriptionPassRegexp             = playDescriptionRegexp(`(NAME) pass(?: (incomplete))?(?: (short|deep) (left|middle|right))?(?: (?:to|intended for) (NAME))?`)
+	playDescriptionPassResultRegexp       = playDescriptionRegexp(` is (complete|incomplete)`)
+	playDescriptionInterceptionRegexp     = playDescriptionRegexp(` INTERCEPTED by (NAME)(?: \((NAME)\))?(?: \[(NAMES)\])? at (SPOT)`)
+	playDescriptionRunRegexp              = playDescriptionRegexp(`(NAME) (?:(scrambles|rushes) )?(left end|left tackle|left guard|up the middle|right guard|right tackle|right end)`)
+	playDescriptionScrambleRegexp         = playDescriptionRegexp(`(NAME) (scrambles|rushes)`)
+	playDescriptionSackRegexp             = playDescriptionRegexp(`(NAME) (?:is )?sacked(?: (ob))?(?: at (SPOT))?`)
+	playDescriptionKneelRegexp            = playDescriptionRegexp(`(NAME) kneels(?: (-?\d+) yds)?`)
+	playDescriptionSpikeRegexp            = playDescriptionRegexp(`(NAME) spiked the ball(?: to stop the clock)?`)
+	playDescriptionKickoffRegexp          = playDescriptionRegexp(`(NAME) kicks (onside )?(-?\d+) yards? from (SPOT) to (?:(the end zone|end zone)|(SPOT))`)
+	playDescriptionPuntRegexp             = playDescriptionRegexp(`(NAME) punts (-?\d+) yards? to (?:(the end zone|end zone)|(SPOT))`)
+	playDescriptionFieldGoalRegexp        = playDescriptionRegexp(`(NAME) (\d+) yard field goal is (GOOD|No Good|BLOCKED|Blocked)`)
+	playDescriptionExtraPointRegexp       = playDescriptionRegexp(`(NAME) extra point is (GOOD|No Good|BLOCKED|Blocked)`)
+	playDescriptionBlockedByRegexp        = playDescriptionRegexp(` \((NAME)\)`)
+	playDescriptionMissReasonRegexp       = playDescriptionRegexp(`(Wide Right|Wide Left|Short|Hit Right Upright|Hit Left Upright|Hit Crossbar)`)
+	playDescriptionNullifiedRegexp        = playDescriptionRegexp(`NULLIFIED by Penalty`)
+	playDescriptionCenterRegexp           = playDescriptionRegexp(`Center-(NAME)`)
+	playDescriptionHolderRegexp           = playDescriptionRegexp(`Holder-(NAME)`)
+	playDescriptionKickOutcomeRegexp      = playDescriptionRegexp(`(Touchback|out of bounds)`)
+	playDescriptionFairCatchRegexp        = playDescriptionRegexp(`fair catch by (NAME)`)
+	playDescriptionDownedRegexp           = playDescriptionRegexp(`downed by (TEAM(?:-NAME)?)`)
+	playDescriptionFumbleRegexp           = playDescriptionRegexp(`(?:(NAME) )?(FUMBLES|MUFFS catch|muffs catch)(?: \((Aborted)\))?(?: \((NAME)\))?(?: \[NAMES\])?(?: at (SPOT))?`)
+	playDescriptionRecoversRegexp         = playDescriptionRegexp(`(?:and )?recovers at (SPOT)`)
+	playDescriptionRecoveredRegexp        = playDescriptionRegexp(`(?:RECOVERED|recovered) by (TEAM)(?:-(NAME))?(?: at (SPOT))?`)
+	playDescriptionTouchedRegexp          = playDescriptionRegexp(`touched at (SPOT)`)
+	playDescriptionOutOfBoundsRegexp      = playDescriptionRegexp(`ball out of bounds at (SPOT)`)
+	playDescriptionBlockedPuntRegexp      = playDescriptionRegexp(`(NAME) punt is BLOCKED by (NAME)`)
+	playDescriptionEndZoneRegexp          = playDescriptionRegexp(`ball out of bounds in End Zone`)
+	playDescriptionStandaloneScoreRegexp  = playDescriptionRegexp(`(TOUCHDOWN|SAFETY)(?: NULLIFIED by Penalty)?`)
+	playDescriptionCaughtAtRegexp         = playDescriptionRegexp(`Caught at (SPOT)`)
+	playDescriptionYardsAfterCatchRegexp  = playDescriptionRegexp(`(-?\d+)[- ](?:yac|YAC)`)
+	playDescriptionAirYardsRegexp         = playDescriptionRegexp(`Pass (-?\d+), YAC (-?\d+)`)
+	playDescriptionReviewRegexp           = playDescriptionRegexp(`(?:The Replay Official|[A-Z][A-Za-z .]+?) (?:reviewed|challenged) the (?:play for possible .+?|.+? ruling), and the play was (Upheld|REVERSED)`)
+	playDescriptionReviewStandsRegexp     = playDescriptionRegexp(`The ruling on the field (?:stands|was confirmed)`)
+	playDescriptionReviewTimeoutRegexp    = playDescriptionRegexp(`\(Timeout #\d(?: at \d*:\d\d)?\.?\)`)
+	playDescriptionImpetusRegexp          = playDescriptionRegexp(`impetus ends at SPOT`)
+	playDescriptionAbortedRegexp          = playDescriptionRegexp(`(NAME) Aborted`)
+	playDescriptionLateralRegexp          = playDescriptionRegexp(`(Lateral|Handoff|Pass back) to (NAME)`)
+	playDescriptionCarryRegexp            = playDescriptionRegexp(`(NAME)`)
+	playDescriptionPenaltyRegexp          = playDescriptionRegexp(`(?:PENALTY|Penalty) on TEAM(?:-NAME)?, [^,]+(?:, (?:\d+ yards?|declined|offsetting))?(?:, (?:enforced (?:at SPOT|between downs|in End Zone)))?( - No Play)?`)
+	playDescriptionInjuryRegexp           = playDescriptionRegexp(`TEAM-(NAME) was injured during the play`)
+	playDescriptionInjuryStatusRegexp     = playDescriptionRegexp(`(?:His return is [A-Za-z]+|He is Out)`)
+)
+
+type playDescriptionParser struct {
+	info *PlayDescriptionInfo
+	rest string
+
+	// The kind of the next carry by a player with no other context.
+	nextCarryKind PlayDescriptionCarryKind
+
+	// The separator that was skipped before the current position.
+	skipped string
+
+	// Set by "<name> Aborted." to mark the following fumble as a fumbled snap.
+	aborted bool
+
+	penalized bool
+
+	// Set when the play type was inferred from a carry with no other context, and can be
+	// replaced by a more specific clause.
+	tentative bool
+}
+
+// ParsePlayDescription parses the text of a play description into its parts. It works on either
+// PlayDescription or PlayDescriptionWithJerseyNumbers. Text that isn't understood is returned in
+// Unparsed.
+func ParsePlayDescription(description string) *PlayDescriptionInfo {
+	info := &PlayDescriptionInfo{
+		Penalties: ParsePlayDescriptionPenalties(description),
+		Reviews:   ParsePlayDescriptionReviews(description),
+	}
+	p := &playDescriptionParser{
+		info:          info,
+		rest:          strings.TrimSpace(description),
+		nextCarryKind: PlayDescriptionCarryKindRun,
+	}
+
+	// Whether the previous word was unparsed.
+	unparsed := false
+
+	for {
+		p.skipped = ""
+		if m := p.consume(playDescriptionSeparatorRegexp); m != nil {
+			p.skipped = m[0]
+		}
+		if p.rest == "" {
+			break
+		}
+		if p.parseClause() {
+			unparsed = false
+			continue
+		}
+
+		// Skip a word and try again. Consecutive skipped words are reported together.
+		word := p.consume(playDescriptionWordRegexp)[0]
+		if unparsed {
+			info.Unparsed[len(info.Unparsed)-1] += p.skipped + word
+		} else {
+			info.Unparsed = append(info.Unparsed, word)
+		}
+		unparsed = true
+	}
+	for i, s := range info.Unparsed {
+		info.Unparsed[i] = strings.TrimRight(s, ".,;")
+	}
+
+	p.finish(info)
+	return info
+}
+
+// Fills in the fields that are derived from the rest of the info.
+func (p *playDescriptionParser) finish(info *PlayDescriptionInfo) {
+	if info.Type == PlayDescriptionTypeUnknown {
+		if len(info.Fumbles) > 0 && info.Fumbles[0].Aborted {
+			info.Type = PlayDescriptionTypeAbortedSnap
+		} else if p.penalized {
+			info.Type = PlayDescriptionTypeNoPlay
+		}
+	}
+	for _, c := range info.Carries {
+		info.Touchdown = info.Touchdown || c.Touchdown
+		info.Safety = info.Safety || c.Safety
+	}
+}
+
+// Consumes the given regular expression from the start of the remaining text and returns its
+// submatches, or nil if it doesn't match.
+func (p *playDescriptionParser) consume(re *regexp.Regexp) []string {
+	m := re.FindStringSubmatch(p.rest)
+	if m == nil {
+		return nil
+	}
+	p.rest = p.rest[len(m[0]):]
+	return m
+}
+
+func (p *playDescriptionParser) setType(t PlayDescriptionType) {
+	if p.info.Type == PlayDescriptionTypeUnknown || p.tentative {
+		if p.tentative {
+			p.info.Rusher = ""
+			p.tentative = false
+		}
+		p.info.Type = t
+	}
+}
+
+func parsePlayDescriptionYardLine(s string) *YardLine {
+	parts := strings.Split(s, " ")
+	number, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return nil
+	}
+	if len(parts) > 1 {
+		return NewYardLine(parts[0], number)
+	}
+	return NewYardLine("", number)
+}
+
+func parsePlayDescriptionNames(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return playDescriptionNameListSeparatorRegexp.Split(s, -1)
+}
+
+// Parses the end of a carry: where it ended, how many yards it gained, and who made the tackle.
+// Returns false if there's nothing there.
+func (p *playDescriptionParser) carryTail(c *PlayDescriptionCarry) bool {
+	before := p.rest
+	if m := p.consume(playDescriptionCarryTailRegexp); m != nil {
+		if m[1] != "" {
+			c.OutOfBounds = true
+			c.Spot = parsePlayDescriptionYardLine(m[2])
+		} else if m[3] != "" {
+			c.Spot = parsePlayDescriptionYardLine(m[3])
+		} else if m[4] != "" {
+			c.Spot = parsePlayDescriptionYardLine(m[4])
+		}
+		if m[6] != "" {
+			c.Yards, _ = strconv.Atoi(m[6])
+		}
+	}
+	if m := p.consume(playDescriptionScoreRegexp); m != nil {
+		switch m[1] {
+		case "TOUCHDOWN":
+			c.Touchdown = true
+		case "SAFETY":
+			c.Safety = true
+		}
+	}
+	if m := p.consume(playDescriptionTacklersRegexp); m != nil {
+		c.Tacklers = parsePlayDescriptionNames(m[1])
+	}
+	if m := p.consume(playDescriptionHitsRegexp); m != nil {
+		p.info.QuarterbackHits = append(p.info.QuarterbackHits, parsePlayDescriptionNames(m[1])...)
+	}
+	return p.rest != before
+}
+
+func (p *playDescriptionParser) addCarry(c PlayDescriptionCarry) {
+	p.info.Carries = append(p.info.Carries, c)
+}
+
+// Parses a single clause at the start of the remaining text. Returns false if nothing matched.
+func (p *playDescriptionParser) parseClause() bool {
+	info := p.info
+
+	if m := p.consume(playDescriptionClockRegexp); m != nil {
+		info.Clock.unmarshal(m[1])
+		return true
+	}
+
+	if m := p.consume(playDescriptionFormationRegexp); m != nil {
+		info.Formations = append(info.Formations, strings.Split(m[1], ", ")...)
+		return true
+	}
+
+	if m := p.consume(playDescriptionPenaltyRegexp); m != nil {
+		p.penalized = true
+		if m[1] != "" {
+			info.NoPlay = true
+		}
+		return true
+	}
+
+	if m := p.consume(playDescriptionReportedEligibleRegexp); m != nil {
+		info.ReportedEligible = append(info.ReportedEligible, parsePlayDescriptionNames(m[1])...)
+		return true
+	}
+
+	if m := p.consume(playDescriptionDirectSnapRegexp); m != nil {
+		info.DirectSnapTo = m[1]
+		return true
+	}
+
+	if p.consume(playDescriptionTwoPointRegexp) != nil {
+		info.TwoPointAttempt = true
+		return true
+	}
+
+	if m := p.consume(playDescriptionTwoPointResultRegexp); m != nil {
+		info.TwoPointSucceeded = m[1] == "SUCCEEDS"
+		return true
+	}
+
+	if m := p.consume(playDescriptionPassRegexp); m != nil {
+		p.setType(PlayDescriptionTypePass)
+		info.Passer = m[1]
+		info.PassDepth = m[3]
+		info.PassLocation = m[4]
+		info.Receiver = m[5]
+		incomplete := m[2] != ""
+		if m := p.consume(playDescriptionPassResultRegexp); m != nil {
+			incomplete = m[1] == "incomplete"
+		}
+		if m := p.consume(playDescriptionInterceptionRegexp); m != nil {
+			info.InterceptedBy = m[1]
+			if m[2] != "" {
+				info.PassDefensedBy = []string{m[2]}
+			}
+			info.QuarterbackHits = append(info.QuarterbackHits, parsePlayDescriptionNames(m[3])...)
+			info.InterceptedAt = parsePlayDescriptionYardLine(m[4])
+			p.nextCarryKind = PlayDescriptionCarryKindInterceptionReturn
+			return true
+		}
+		if incomplete {
+			if m := p.consume(playDescriptionTacklersRegexp); m != nil {
+				info.PassDefensedBy = parsePlayDescriptionNames(m[1])
+			}
+			if m := p.consume(playDescriptionHitsRegexp); m != nil {
+				info.QuarterbackHits = append(info.QuarterbackHits, parsePlayDescriptionNames(m[1])...)
+			}
+			return true
+		}
+		info.Complete = true
+		c := PlayDescriptionCarry{
+			Kind:   PlayDescriptionCarryKindReception,
+			Player: info.Receiver,
+		}
+		p.carryTail(&c)
+		p.addCarry(c)
+		return true
+	}
+
+	if m := p.consume(playDescriptionSackRegexp); m != nil {
+		p.setType(PlayDescriptionTypeSack)
+		info.Passer = m[1]
+		c := PlayDescriptionCarry{
+			Kind:        PlayDescriptionCarryKindSack,
+			Player:      m[1],
+			OutOfBounds: m[2] != "",
+			Spot:        parsePlayDescriptionYardLine(m[3]),
+		}
+		p.carryTail(&c)
+		p.addCarry(c)
+		return true
+	}
+
+	if m := p.consume(playDescriptionKneelRegexp); m != nil {
+		p.setType(PlayDescriptionTypeKneel)
+		info.Rusher = m[1]
+		c := PlayDescriptionCarry{
+			Kind:   PlayDescriptionCarryKindKneel,
+			Player: m[1],
+		}
+		if m[2] != "" {
+			c.Yards, _ = strconv.Atoi(m[2])
+		}
+		p.carryTail(&c)
+		p.addCarry(c)
+		return true
+	}
+
+	if m := p.consume(playDescriptionSpikeRegexp); m != nil {
+		p.setType(PlayDescriptionTypeSpike)
+		info.Passer = m[1]
+		return true
+	}
+
+	if m := p.consume(playDescriptionRunRegexp); m != nil {
+		p.run(m[1], m[2], m[3])
+		return true
+	}
+
+	if m := p.consume(playDescriptionScrambleRegexp); m != nil {
+		p.run(m[1], m[2], "")
+		return true
+	}
+
+	if m := p.consume(playDescriptionKickoffRegexp); m != nil {
+		p.setType(PlayDescriptionTypeKickoff)
+		info.Kicker = m[1]
+		info.Onside = m[2] != ""
+		info.KickYards, _ = strconv.Atoi(m[3])
+		info.KickFrom = parsePlayDescriptionYardLine(m[4])
+		info.KickToEndZone = m[5] != ""
+		info.KickTo = parsePlayDescriptionYardLine(m[6])
+		p.nextCarryKind = PlayDescriptionCarryKindReturn
+		return true
+	}
+
+	if m := p.consume(playDescriptionPuntRegexp); m != nil {
+		p.setType(PlayDescriptionTypePunt)
+		info.Kicker = m[1]
+		info.KickYards, _ = strconv.Atoi(m[2])
+		info.KickToEndZone = m[3] != ""
+		info.KickTo = parsePlayDescriptionYardLine(m[4])
+		p.nextCarryKind = PlayDescriptionCarryKindReturn
+		return true
+	}
+
+	if m := p.consume(playDescriptionBlockedPuntRegexp); m != nil {
+		p.setType(PlayDescriptionTypePunt)
+		info.Kicker = m[1]
+		info.KickResult = KickResultBlocked
+		info.BlockedBy = m[2]
+		p.nextCarryKind = PlayDescriptionCarryKindReturn
+		return true
+	}
+
+	if m := p.consume(playDescriptionFieldGoalRegexp); m != nil {
+		p.setType(PlayDescriptionTypeFieldGoal)
+		info.Kicker = m[1]
+		info.KickYards, _ = strconv.Atoi(m[2])
+		p.kickResult(m[3])
+		return true
+	}
+
+	if m := p.consume(playDescriptionExtraPointRegexp); m != nil {
+		p.setType(PlayDescriptionTypeExtraPoint)
+		info.Kicker = m[1]
+		p.kickResult(m[2])
+		return true
+	}
+
+	if m := p.consume(playDescriptionMissReasonRegexp); m != nil {
+		info.KickMissReason = m[1]
+		return true
+	}
+
+	if p.consume(playDescriptionNullifiedRegexp) != nil {
+		return true
+	}
+
+	if m := p.consume(playDescriptionCenterRegexp); m != nil {
+		info.LongSnapper = m[1]
+		return true
+	}
+
+	if m := p.consume(playDescriptionHolderRegexp); m != nil {
+		info.Holder = m[1]
+		return true
+	}
+
+	if m := p.consume(playDescriptionKickOutcomeRegexp); m != nil {
+		if info.Kicker == "" {
+			// Touchbacks also happen on interceptions and fumbles.
+		} else if m[1] == "Touchback" {
+			info.KickResult = KickResultTouchback
+		} else {
+			info.KickResult = KickResultOutOfBounds
+		}
+		return true
+	}
+
+	if m := p.consume(playDescriptionFairCatchRegexp); m != nil {
+		info.KickResult = KickResultFairCatch
+		info.FairCatchBy = m[1]
+		return true
+	}
+
+	if m := p.consume(playDescriptionDownedRegexp); m != nil {
+		info.KickResult = KickResultDowned
+		info.DownedBy = m[1]
+		return true
+	}
+
+	if p.consume(playDescriptionAbortedRegexp) != nil {
+		p.aborted = true
+		return true
+	}
+
+	if m := p.consume(playDescriptionFumbleRegexp); m != nil {
+		f := PlayDescriptionFumble{
+			Player:   m[1],
+			Muffed:   m[2] != "FUMBLES",
+			Aborted:  m[3] != "" || p.aborted,
+			ForcedBy: m[4],
+			At:       parsePlayDescriptionYardLine(m[5]),
+		}
+		if f.Player == "" && len(info.Carries) > 0 {
+			f.Player = info.Carries[len(info.Carries)-1].Player
+		} else if f.Player == "" && f.Aborted {
+			f.Player = info.Passer
+		}
+		if f.Muffed {
+			info.KickResult = KickResultMuffed
+		}
+		p.aborted = false
+		info.Fumbles = append(info.Fumbles, f)
+		p.nextCarryKind = PlayDescriptionCarryKindFumbleReturn
+		return true
+	}
+
+	if len(info.Fumbles) == 0 && (info.Type == PlayDescriptionTypeKickoff || info.KickResult == KickResultBlocked) {
+		if m := p.consume(playDescriptionRecoveredRegexp); m != nil {
+			info.KickRecoveredByTeam = m[1]
+			info.KickRecoveredBy = m[2]
+			info.KickRecoveredAt = parsePlayDescriptionYardLine(m[3])
+			return true
+		}
+		if p.consume(playDescriptionOutOfBoundsRegexp) != nil {
+			return true
+		}
+	}
+
+	if len(info.Fumbles) > 0 {
+		f := &info.Fumbles[len(info.Fumbles)-1]
+		if m := p.consume(playDescriptionRecoversRegexp); m != nil {
+			f.RecoveredBy = f.Player
+			f.RecoveredAt = parsePlayDescriptionYardLine(m[1])
+			return true
+		}
+		if m := p.consume(playDescriptionRecoveredRegexp); m != nil {
+			f.RecoveredByTeam = m[1]
+			f.RecoveredBy = m[2]
+			f.RecoveredAt = parsePlayDescriptionYardLine(m[3])
+			return true
+		}
+		if m := p.consume(playDescriptionTouchedRegexp); m != nil {
+			return true
+		}
+		if m := p.consume(playDescriptionOutOfBoundsRegexp); m != nil {
+			f.OutOfBoundsAt = parsePlayDescriptionYardLine(m[1])
+			return true
+		}
+	}
+
+	if p.consume(playDescriptionEndZoneRegexp) != nil || p.consume(playDescriptionImpetusRegexp) != nil {
+		return true
+	}
+
+	if m := p.consume(playDescriptionStandaloneScoreRegexp); m != nil {
+		if m[0] == "TOUCHDOWN" {
+			info.Touchdown = true
+		} else if m[0] == "SAFETY" {
+			info.Safety = true
+		}
+		return true
+	}
+
+	if m := p.consume(playDescriptionCaughtAtRegexp); m != nil {
+		info.CaughtAt = parsePlayDescriptionYardLine(m[1])
+		return true
+	}
+
+	if m := p.consume(playDescriptionYardsAfterCatchRegexp); m != nil {
+		yac, _ := strconv.Atoi(m[1])
+		info.YardsAfterCatch = &yac
+		return true
+	}
+
+	if m := p.consume(playDescriptionAirYardsRegexp); m != nil {
+		air, _ := strconv.Atoi(m[1])
+		yac, _ := strconv.Atoi(m[2])
+		info.AirYards = &air
+		info.YardsAfterCatch = &yac
+		return true
+	}
+
+	if m := p.consume(playDescriptionReviewRegexp); m != nil {
+		if m[1] == "REVERSED" {
+			// What follows is the play as it stands after the review.
+			overturned := *info
+			*info = PlayDescriptionInfo{
+				Clock:      overturned.Clock,
+				Formations: overturned.Formations,
+				Penalties:  overturned.Penalties,
+				Reviews:    overturned.Reviews,
+				Unparsed:   overturned.Unparsed,
+				Overturned: &overturned,
+			}
+			overturned.Penalties = nil
+			overturned.Reviews = nil
+			overturned.Unparsed = nil
+			p.finish(&overturned)
+			p.nextCarryKind = PlayDescriptionCarryKindRun
+			p.penalized = false
+			p.aborted = false
+		}
+		return true
+	}
+
+	if p.consume(playDescriptionReviewStandsRegexp) != nil || p.consume(playDescriptionReviewTimeoutRegexp) != nil {
+		return true
+	}
+
+	if m := p.consume(playDescriptionLateralRegexp); m != nil {
+		c := PlayDescriptionCarry{
+			Kind:   PlayDescriptionCarryKindLateral,
+			Player: m[2],
+		}
+		if m[1] == "Handoff" {
+			c.Kind = PlayDescriptionCarryKindRun
+			p.setType(PlayDescriptionTypeRun)
+			info.Rusher = c.Player
+		}
+		p.carryTail(&c)
+		p.addCarry(c)
+		return true
+	}
+
+	if m := p.consume(playDescriptionInjuryRegexp); m != nil {
+		info.Injured = append(info.Injured, m[1])
+		return true
+	}
+
+	if p.consume(playDescriptionInjuryStatusRegexp) != nil {
+		return true
+	}
+
+	// A player advancing the ball without any other context, e.g. a kick returner.
+	before := p.rest
+	if m := p.consume(playDescriptionCarryRegexp); m != nil {
+		c := PlayDescriptionCarry{
+			Kind:   p.nextCarryKind,
+			Player: m[1],
+		}
+		if p.carryTail(&c) {
+			if c.Kind == PlayDescriptionCarryKindRun && info.Type == PlayDescriptionTypeUnknown {
+				// This is usually a run after a direct snap, but it's sometimes a quarterback
+				// advancing a fumbled snap before the actual play is described.
+				p.setType(PlayDescriptionTypeRun)
+				info.Rusher = c.Player
+				p.tentative = true
+			} else if c.Kind == PlayDescriptionCarryKindReturn && info.KickResult == "" {
+				info.KickResult = KickResultReturned
+			}
+			p.addCarry(c)
+			return true
+		}
+		p.rest = before
+	}
+
+	return false
+}
+
+func (p *playDescriptionParser) run(rusher, kind, direction string) {
+	p.setType(PlayDescriptionTypeRun)
+	p.info.Rusher = rusher
+	p.info.RunDirection = direction
+	p.info.Scramble = kind == "scrambles"
+	c := PlayDescriptionCarry{
+		Kind:   PlayDescriptionCarryKindRun,
+		Player: rusher,
+	}
+	p.carryTail(&c)
+	p.addCarry(c)
+}
+
+func (p *playDescriptionParser) kickResult(result string) {
+	switch result {
+	case "GOOD":
+		p.info.KickResult = KickResultGood
+	case "No Good":
+		p.info.KickResult = KickResultNoGood
+	default:
+		p.info.KickResult = KickResultBlocked
+		if m := p.consume(playDescriptionBlockedByRegexp); m != nil {
+			p.info.BlockedBy = m[1]
+		}
+		p.nextCarryKind = PlayDescriptionCarryKindReturn
+	}
+}
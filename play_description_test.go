@@ -0,0 +1,309 @@
+package gsis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlayDescription(t *testing.T) {
+	t.Run("Pass", func(t *testing.T) {
+		info := ParsePlayDescription("(10:28) (No Huddle, Shotgun) 14-R.Fitzpatrick pass short middle to 11-D.Parker to NE 23 for 23 yards (27-J.Jackson, 24-S.Gilmore) [70-A.Butler].")
+		assert.Equal(t, "10:28", info.Clock.raw)
+		assert.Equal(t, []string{"No Huddle", "Shotgun"}, info.Formations)
+		assert.Equal(t, PlayDescriptionTypePass, info.Type)
+		assert.Equal(t, "14-R.Fitzpatrick", info.Passer)
+		assert.Equal(t, "11-D.Parker", info.Receiver)
+		assert.Equal(t, "short", info.PassDepth)
+		assert.Equal(t, "middle", info.PassLocation)
+		assert.True(t, info.Complete)
+		assert.Equal(t, []string{"70-A.Butler"}, info.QuarterbackHits)
+		assert.Equal(t, []PlayDescriptionCarry{{
+			Kind:     PlayDescriptionCarryKindReception,
+			Player:   "11-D.Parker",
+			Spot:     NewYardLine("NE", 23),
+			Yards:    23,
+			Tacklers: []string{"27-J.Jackson", "24-S.Gilmore"},
+		}}, info.Carries)
+		assert.Empty(t, info.Unparsed)
+	})
+
+	t.Run("IncompletePass", func(t *testing.T) {
+		info := ParsePlayDescription("(2:17) (Shotgun) C.Keenum pass incomplete deep left to H.Hentges (J.Heath). Pass incomplete on a \"seam\" route.")
+		assert.Equal(t, PlayDescriptionTypePass, info.Type)
+		assert.False(t, info.Complete)
+		assert.Equal(t, "deep", info.PassDepth)
+		assert.Equal(t, "left", info.PassLocation)
+		assert.Equal(t, "H.Hentges", info.Receiver)
+		assert.Equal(t, []string{"J.Heath"}, info.PassDefensedBy)
+		assert.Empty(t, info.Carries)
+		assert.Equal(t, []string{`Pass incomplete on a "seam" route`}, info.Unparsed)
+	})
+
+	t.Run("Run", func(t *testing.T) {
+		info := ParsePlayDescription("(12:49) G.Fant reported in as eligible.  C.Carson right tackle to SEA 19 for -2 yards (B.Baker).")
+		assert.Equal(t, PlayDescriptionTypeRun, info.Type)
+		assert.Equal(t, []string{"G.Fant"}, info.ReportedEligible)
+		assert.Equal(t, "C.Carson", info.Rusher)
+		assert.Equal(t, "right tackle", info.RunDirection)
+		require.Len(t, info.Carries, 1)
+		assert.Equal(t, -2, info.Carries[0].Yards)
+		assert.Equal(t, NewYardLine("SEA", 19), info.Carries[0].Spot)
+		assert.Empty(t, info.Unparsed)
+	})
+
+	t.Run("Scramble", func(t *testing.T) {
+		info := ParsePlayDescription("(5:05) (Shotgun) A.Smith scrambles left end pushed ob at GB 9 for 7 yards (J.Whitehead).")
+		assert.Equal(t, PlayDescriptionTypeRun, info.Type)
+		assert.True(t, info.Scramble)
+		require.Len(t, info.Carries, 1)
+		assert.True(t, info.Carries[0].OutOfBounds)
+		assert.Equal(t, NewYardLine("GB", 9), info.Carries[0].Spot)
+	})
+
+	t.Run("Touchdown", func(t *testing.T) {
+		info := ParsePlayDescription("(5:39) M.Ingram left guard for 1 yard, TOUCHDOWN. NO G 67 L. Warford injured on the play")
+		assert.Equal(t, PlayDescriptionTypeRun, info.Type)
+		assert.True(t, info.Touchdown)
+		assert.Equal(t, 1, info.Carries[0].Yards)
+		assert.Equal(t, []string{"NO G 67 L. Warford injured on the play"}, info.Unparsed)
+	})
+
+	t.Run("SplitSack", func(t *testing.T) {
+		info := ParsePlayDescription("(6:31) (Shotgun) M.Ryan sacked at ATL 10 for -8 yards (sack split by C.Long and F.Cox). FUMBLES (C.Long) [C.Long], recovered by ATL-A.Mack at ATL 15. A.Mack to ATL 15 for no gain (M.Bennett).")
+		assert.Equal(t, PlayDescriptionTypeSack, info.Type)
+		assert.Equal(t, "M.Ryan", info.Passer)
+		require.Len(t, info.Carries, 2)
+		assert.Equal(t, []string{"C.Long", "F.Cox"}, info.Carries[0].Tacklers)
+		assert.Equal(t, -8, info.Carries[0].Yards)
+		assert.Equal(t, PlayDescriptionCarryKindFumbleReturn, info.Carries[1].Kind)
+		assert.Equal(t, []PlayDescriptionFumble{{
+			Player:          "M.Ryan",
+			ForcedBy:        "C.Long",
+			RecoveredBy:     "A.Mack",
+			RecoveredByTeam: "ATL",
+			RecoveredAt:     NewYardLine("ATL", 15),
+		}}, info.Fumbles)
+		assert.Empty(t, info.Unparsed)
+	})
+
+	t.Run("KneelAndSpike", func(t *testing.T) {
+		info := ParsePlayDescription("(:40) R.Wilson kneels to SEA 29 for -1 yards.")
+		assert.Equal(t, PlayDescriptionTypeKneel, info.Type)
+		assert.Equal(t, -1, info.Carries[0].Yards)
+
+		info = ParsePlayDescription("(:01) (No Huddle) R.Tannehill spiked the ball to stop the clock.")
+		assert.Equal(t, PlayDescriptionTypeSpike, info.Type)
+		assert.Equal(t, "R.Tannehill", info.Passer)
+		assert.Empty(t, info.Unparsed)
+	})
+
+	t.Run("Kickoff", func(t *testing.T) {
+		info := ParsePlayDescription("K.Fairbairn kicks 63 yards from HST 35 to TB 2. D.Ogunbowale to TB 20 for 18 yards (A.Moore).")
+		assert.Equal(t, PlayDescriptionTypeKickoff, info.Type)
+		assert.Equal(t, "K.Fairbairn", info.Kicker)
+		assert.Equal(t, 63, info.KickYards)
+		assert.Equal(t, NewYardLine("HST", 35), info.KickFrom)
+		assert.Equal(t, NewYardLine("TB", 2), info.KickTo)
+		assert.Equal(t, KickResultReturned, info.KickResult)
+		require.Len(t, info.Carries, 1)
+		assert.Equal(t, PlayDescriptionCarryKindReturn, info.Carries[0].Kind)
+		assert.Equal(t, "D.Ogunbowale", info.Carries[0].Player)
+		assert.Equal(t, 18, info.Carries[0].Yards)
+
+		info = ParsePlayDescription("M.Bosher kicks 65 yards from ATL 35 to end zone, Touchback.")
+		assert.True(t, info.KickToEndZone)
+		assert.Equal(t, KickResultTouchback, info.KickResult)
+		assert.Empty(t, info.Carries)
+	})
+
+	t.Run("OnsideKick", func(t *testing.T) {
+		info := ParsePlayDescription("A.Rosas kicks onside 12 yards from NYG 35 to NYG 47, impetus ends at NYG 46. RECOVERED by NYG-M.Thomas.")
+		assert.Equal(t, PlayDescriptionTypeKickoff, info.Type)
+		assert.True(t, info.Onside)
+		assert.Equal(t, "NYG", info.KickRecoveredByTeam)
+		assert.Equal(t, "M.Thomas", info.KickRecoveredBy)
+		assert.Empty(t, info.Unparsed)
+	})
+
+	t.Run("Punt", func(t *testing.T) {
+		info := ParsePlayDescription("(9:20) (Punt formation) A.Lee punts 52 yards to SEA 6, Center-A.Brewer, fair catch by D.Moore.")
+		assert.Equal(t, PlayDescriptionTypePunt, info.Type)
+		assert.Equal(t, []string{"Punt formation"}, info.Formations)
+		assert.Equal(t, 52, info.KickYards)
+		assert.Equal(t, "A.Brewer", info.LongSnapper)
+		assert.Equal(t, KickResultFairCatch, info.KickResult)
+		assert.Equal(t, "D.Moore", info.FairCatchBy)
+
+		info = ParsePlayDescription("(12:48) (Punt formation) 10-K.Huber punts 43 yards to PIT 17, Center-46-C.Harris, downed by CIN.")
+		assert.Equal(t, KickResultDowned, info.KickResult)
+		assert.Equal(t, "CIN", info.DownedBy)
+		assert.Empty(t, info.Unparsed)
+
+		info = ParsePlayDescription("(4:41) T.Daniel punts 47 yards to NE 17, Center-J.Weeks. R.McCarron MUFFS catch, RECOVERED by HST-J.Bademosi at NE 16. J.Bademosi to NE 16 for no gain (J.Jones).")
+		assert.Equal(t, KickResultMuffed, info.KickResult)
+		require.Len(t, info.Fumbles, 1)
+		assert.True(t, info.Fumbles[0].Muffed)
+		assert.Equal(t, "R.McCarron", info.Fumbles[0].Player)
+		assert.Equal(t, "HST", info.Fumbles[0].RecoveredByTeam)
+
+		info = ParsePlayDescription("(5:19) 2-D.Colquitt punt is BLOCKED by 43-N.Ebner, Center-41-J.Winchester, ball out of bounds at KC 19.")
+		assert.Equal(t, PlayDescriptionTypePunt, info.Type)
+		assert.Equal(t, KickResultBlocked, info.KickResult)
+		assert.Equal(t, "43-N.Ebner", info.BlockedBy)
+		assert.Empty(t, info.Unparsed)
+	})
+
+	t.Run("FieldGoal", func(t *testing.T) {
+		info := ParsePlayDescription("(Field Goal formation) J.Myers 48 yard field goal is No Good, Wide Right, Center-T.Ott, Holder-M.Dickson.")
+		assert.Equal(t, PlayDescriptionTypeFieldGoal, info.Type)
+		assert.Equal(t, 48, info.KickYards)
+		assert.Equal(t, KickResultNoGood, info.KickResult)
+		assert.Equal(t, "Wide Right", info.KickMissReason)
+		assert.Equal(t, "T.Ott", info.LongSnapper)
+		assert.Equal(t, "M.Dickson", info.Holder)
+		assert.Empty(t, info.Unparsed)
+	})
+
+	t.Run("ExtraPoint", func(t *testing.T) {
+		info := ParsePlayDescription("D.Hopkins extra point is GOOD, Center-N.Sundberg, Holder-T.Way.")
+		assert.Equal(t, PlayDescriptionTypeExtraPoint, info.Type)
+		assert.Equal(t, KickResultGood, info.KickResult)
+		assert.Empty(t, info.Unparsed)
+	})
+
+	t.Run("TwoPointAttempt", func(t *testing.T) {
+		info := ParsePlayDescription("(Pass formation) TWO-POINT CONVERSION ATTEMPT. T.Brady pass to J.Edelman is complete. ATTEMPT SUCCEEDS.")
+		assert.Equal(t, PlayDescriptionTypePass, info.Type)
+		assert.True(t, info.TwoPointAttempt)
+		assert.True(t, info.TwoPointSucceeded)
+		assert.True(t, info.Complete)
+		assert.Equal(t, "J.Edelman", info.Receiver)
+		assert.Empty(t, info.Unparsed)
+	})
+
+	t.Run("InterceptionWithLaterals", func(t *testing.T) {
+		info := ParsePlayDescription("(4:37) (Shotgun) 8-K.Cousins pass deep right intended for 19G-K.Golladay INTERCEPTED by 29-E.Thomas [97H-C.Heyward] at AFC 24. 29-E.Thomas to NFC 35 for 41 yards. Lateral to 44-M.Humphrey to NFC 35 for no gain. Lateral to 99J-M.Judon to NFC 35 for no gain (19C-A.Cooper).")
+		assert.Equal(t, PlayDescriptionTypePass, info.Type)
+		assert.False(t, info.Complete)
+		assert.Equal(t, "19G-K.Golladay", info.Receiver)
+		assert.Equal(t, "29-E.Thomas", info.InterceptedBy)
+		assert.Equal(t, NewYardLine("AFC", 24), info.InterceptedAt)
+		assert.Equal(t, []string{"97H-C.Heyward"}, info.QuarterbackHits)
+		require.Len(t, info.Carries, 3)
+		assert.Equal(t, PlayDescriptionCarryKindInterceptionReturn, info.Carries[0].Kind)
+		assert.Equal(t, 41, info.Carries[0].Yards)
+		assert.Equal(t, PlayDescriptionCarryKindLateral, info.Carries[1].Kind)
+		assert.Equal(t, "44-M.Humphrey", info.Carries[1].Player)
+		assert.Equal(t, []string{"19C-A.Cooper"}, info.Carries[2].Tacklers)
+		assert.Empty(t, info.Unparsed)
+	})
+
+	t.Run("AbortedSnap", func(t *testing.T) {
+		info := ParsePlayDescription("(2:19) (Shotgun) C.Wentz FUMBLES (Aborted) at WAS 14, and recovers at WAS 14. C.Wentz to WAS 7 for 7 yards (C.Holcomb).")
+		assert.Equal(t, PlayDescriptionTypeAbortedSnap, info.Type)
+		require.Len(t, info.Fumbles, 1)
+		assert.True(t, info.Fumbles[0].Aborted)
+		assert.Equal(t, "C.Wentz", info.Fumbles[0].RecoveredBy)
+		require.Len(t, info.Carries, 1)
+		assert.Equal(t, PlayDescriptionCarryKindFumbleReturn, info.Carries[0].Kind)
+		assert.Empty(t, info.Unparsed)
+	})
+
+	t.Run("NoPlay", func(t *testing.T) {
+		info := ParsePlayDescription("(7:14) (Punt formation) PENALTY on SEA-M.Blair, False Start, 5 yards, enforced at SEA 13 - No Play.")
+		assert.Equal(t, PlayDescriptionTypeNoPlay, info.Type)
+		assert.True(t, info.NoPlay)
+		require.Len(t, info.Penalties, 1)
+		assert.Equal(t, FoulCodeFalseStart, info.Penalties[0].FoulCode)
+		assert.Empty(t, info.Unparsed)
+	})
+
+	t.Run("Reversed", func(t *testing.T) {
+		info := ParsePlayDescription("(:51) (Shotgun) 8-C.Keenum pass incomplete short right to 15-S.Sims (27-J.Lewis). The Replay Official reviewed the incomplete pass ruling, and the play was REVERSED. (Shotgun) 8-C.Keenum pass short right to 15-S.Sims to DAL 11 for 9 yards (27-J.Lewis).")
+		assert.Equal(t, PlayDescriptionTypePass, info.Type)
+		assert.True(t, info.Complete)
+		require.Len(t, info.Carries, 1)
+		assert.Equal(t, 9, info.Carries[0].Yards)
+		require.NotNil(t, info.Overturned)
+		assert.Equal(t, PlayDescriptionTypePass, info.Overturned.Type)
+		assert.False(t, info.Overturned.Complete)
+		assert.Empty(t, info.Overturned.Reviews)
+		require.Len(t, info.Reviews, 1)
+		assert.Equal(t, ReviewInitiatorBooth, info.Reviews[0].Initiator)
+		assert.Equal(t, ReviewOutcomeReversed, info.Reviews[0].Outcome)
+		assert.Empty(t, info.Unparsed)
+	})
+}
+
+func TestParsePlayDescription_Games(t *testing.T) {
+	plays, unparsed := 0, 0
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		playStats := map[StringInt][]StatFilePlayStat{}
+		for _, stat := range stats.PlayStat {
+			playStats[stat.PlayID] = append(playStats[stat.PlayID], stat)
+		}
+
+		for _, p := range stats.Play {
+			if !p.IsActualPlay() || p.PlayType == PlayTypeTimeout {
+				continue
+			}
+			info := ParsePlayDescription(p.PlayDescription)
+			plays++
+			if len(info.Unparsed) > 0 {
+				unparsed++
+			}
+			if !assert.NotEqual(t, PlayDescriptionTypeUnknown, info.Type, p.PlayDescription) {
+				continue
+			}
+
+			withJerseyNumbers := ParsePlayDescription(p.PlayDescriptionWithJerseyNumbers)
+			if p.PlayDescriptionWithJerseyNumbers != "" {
+				assert.Equal(t, info.Type, withJerseyNumbers.Type, p.PlayDescriptionWithJerseyNumbers)
+				assert.Equal(t, len(info.Carries), len(withJerseyNumbers.Carries), p.PlayDescriptionWithJerseyNumbers)
+			}
+
+			carriers := map[string]bool{
+				info.InterceptedBy: true,
+			}
+			for _, c := range info.Carries {
+				carriers[c.Player] = true
+			}
+			for _, f := range info.Fumbles {
+				carriers[f.Player] = true
+			}
+
+			for _, stat := range playStats[p.PlayID] {
+				switch stat.StatID {
+				case StatIDPassingYards, StatIDPassingYardsTD:
+					assert.Equal(t, PlayDescriptionTypePass, info.Type, p.PlayDescription)
+					assert.Equal(t, stat.PlayerName, info.Passer, p.PlayDescription)
+					assert.True(t, info.Complete, p.PlayDescription)
+				case StatIDPassIncomplete:
+					assert.Contains(t, []PlayDescriptionType{PlayDescriptionTypePass, PlayDescriptionTypeSpike}, info.Type, p.PlayDescription)
+					assert.False(t, info.Complete, p.PlayDescription)
+				case StatIDInterceptionPasser:
+					assert.NotEmpty(t, info.InterceptedBy, p.PlayDescription)
+				case StatIDSackYards:
+					assert.Equal(t, PlayDescriptionTypeSack, info.Type, p.PlayDescription)
+					assert.Equal(t, stat.PlayerName, info.Passer, p.PlayDescription)
+				case StatIDRushingYards, StatIDRushingYardsTD, StatIDPassReceptionYards, StatIDPassReceptionYardsTD, StatIDPuntReturnYards, StatIDPuntReturnYardsTD, StatIDKickoffReturnYards, StatIDKickoffReturnYardsTD, StatIDInterceptionYards, StatIDInterceptionYardsTD:
+					if info.Type != PlayDescriptionTypeAbortedSnap {
+						assert.True(t, carriers[stat.PlayerName], "%v: %v", stat.PlayerName, p.PlayDescription)
+					}
+				case StatIDPuntingYards:
+					assert.Equal(t, PlayDescriptionTypePunt, info.Type, p.PlayDescription)
+				case StatIDKickoffYards:
+					assert.Equal(t, PlayDescriptionTypeKickoff, info.Type, p.PlayDescription)
+				case StatIDFieldGoalYards, StatIDFieldGoalMissedYards, StatIDFieldGoalBlockedOffense:
+					assert.Equal(t, PlayDescriptionTypeFieldGoal, info.Type, p.PlayDescription)
+					assert.Equal(t, stat.Yards.Int(), info.KickYards, p.PlayDescription)
+				case StatIDFumbleForced, StatIDFumbleNotForced:
+					assert.NotEmpty(t, info.Fumbles, p.PlayDescription)
+				}
+			}
+		}
+	})
+	t.Logf("%v of %v plays have unparsed text", unparsed, plays)
+}
@@ -0,0 +1,300 @@
+package gsis
+
+import (
+	"regexp"
+	"strings"
+)
+
+type PlayPlayerRole string
+
+const (
+	PlayPlayerRolePasser           PlayPlayerRole = "PASSER"
+	PlayPlayerRoleTarget           PlayPlayerRole = "TARGET"
+	PlayPlayerRoleRusher           PlayPlayerRole = "RUSHER"
+	PlayPlayerRoleSacked           PlayPlayerRole = "SACKED"
+	PlayPlayerRoleBallCarrier      PlayPlayerRole = "BALL_CARRIER"
+	PlayPlayerRoleReturner         PlayPlayerRole = "RETURNER"
+	PlayPlayerRoleKicker           PlayPlayerRole = "KICKER"
+	PlayPlayerRoleLongSnapper      PlayPlayerRole = "LONG_SNAPPER"
+	PlayPlayerRoleHolder           PlayPlayerRole = "HOLDER"
+	PlayPlayerRoleTackler          PlayPlayerRole = "TACKLER"
+	PlayPlayerRoleQuarterbackHit   PlayPlayerRole = "QUARTERBACK_HIT"
+	PlayPlayerRolePassDefender     PlayPlayerRole = "PASS_DEFENDER"
+	PlayPlayerRoleInterceptor      PlayPlayerRole = "INTERCEPTOR"
+	PlayPlayerRoleFumbler          PlayPlayerRole = "FUMBLER"
+	PlayPlayerRoleForcedFumble     PlayPlayerRole = "FORCED_FUMBLE"
+	PlayPlayerRoleRecovery         PlayPlayerRole = "RECOVERY"
+	PlayPlayerRoleBlocker          PlayPlayerRole = "BLOCKER"
+	PlayPlayerRoleFairCatch        PlayPlayerRole = "FAIR_CATCH"
+	PlayPlayerRoleDowned           PlayPlayerRole = "DOWNED"
+	PlayPlayerRoleReportedEligible PlayPlayerRole = "REPORTED_ELIGIBLE"
+	PlayPlayerRoleInjured          PlayPlayerRole = "INJURED"
+	PlayPlayerRolePenalized        PlayPlayerRole = "PENALIZED"
+)
+
+// A player mentioned in a play description.
+type PlayPlayer struct {
+	// The player as they appear in the description, e.g. "3-R.Wilson".
+	Token string
+
+	Name         string
+	JerseyNumber string
+
+	// These are empty if the player couldn't be resolved. The position is only available for
+	// players found in the roster.
+	GSISPlayerID string
+	ClubCode     string
+	Position     string
+
+	// The player's roles in the play, including any in a ruling that was overturned by replay.
+	// This may be empty for players mentioned in text the description parser doesn't understand.
+	Roles []PlayPlayerRole
+
+	// For ambiguous players, the roster entries that could be the player.
+	Candidates []RosterFilePlayer
+}
+
+// HasRole returns true if the player had the given role in the play.
+func (p *PlayPlayer) HasRole(role PlayPlayerRole) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type PlayPlayers struct {
+	// Players that were resolved to exactly one GSIS player.
+	Players []*PlayPlayer
+
+	// Players that matched more than one roster entry.
+	Ambiguous []*PlayPlayer
+
+	// Players that didn't match anyone in the roster or play stats.
+	Unmatched []*PlayPlayer
+}
+
+var (
+	playPlayerTokenRegexp         = regexp.MustCompile(`(?:\b(` + playDescriptionTeamExpr + `)-)?(?:\b(` + playDescriptionJerseyNumberExpr + `)-)?\b(` + playDescriptionPlayerNameExpr + `)`)
+	playPlayerNumberedTokenRegexp = regexp.MustCompile(`(?:\b(` + playDescriptionTeamExpr + `)-)?\b(` + playDescriptionJerseyNumberExpr + `)-(` + playDescriptionPlayerNameExpr + `)`)
+	playPlayerNameRegexp          = regexp.MustCompile(`^(?:(` + playDescriptionJerseyNumberExpr + `)-)?(.*)$`)
+	playPlayerTeamRegexp          = regexp.MustCompile(`^` + playDescriptionTeamExpr + `$`)
+)
+
+func normalizePlayerName(name string) string {
+	return strings.ToLower(strings.Replace(name, " ", "", -1))
+}
+
+func normalizeJerseyNumber(number string) string {
+	return strings.TrimLeft(number, "0")
+}
+
+func playPlayerKey(number, name string) string {
+	return normalizeJerseyNumber(number) + "-" + normalizePlayerName(name)
+}
+
+// Returns the key for a name as it appears in parsed play description info. The name may be
+// preceded by a team, e.g. "SEA-3-R.Wilson".
+func playPlayerKeyForName(s string) string {
+	if i := strings.Index(s, "-"); i > 0 && playPlayerTeamRegexp.MatchString(s[:i]) {
+		s = s[i+1:]
+	}
+	m := playPlayerNameRegexp.FindStringSubmatch(s)
+	return playPlayerKey(m[1], m[2])
+}
+
+// Returns each player's roles in the play, keyed by playPlayerKey.
+func playDescriptionRoles(info *PlayDescriptionInfo) map[string][]PlayPlayerRole {
+	ret := map[string][]PlayPlayerRole{}
+	add := func(role PlayPlayerRole, names ...string) {
+		for _, name := range names {
+			if name == "" || playPlayerTeamRegexp.MatchString(name) {
+				continue
+			}
+			key := playPlayerKeyForName(name)
+			for _, r := range ret[key] {
+				if r == role {
+					key = ""
+					break
+				}
+			}
+			if key != "" {
+				ret[key] = append(ret[key], role)
+			}
+		}
+	}
+
+	for ; info != nil; info = info.Overturned {
+		add(PlayPlayerRoleReportedEligible, info.ReportedEligible...)
+		add(PlayPlayerRoleRusher, info.DirectSnapTo)
+		add(PlayPlayerRolePasser, info.Passer)
+		add(PlayPlayerRoleTarget, info.Receiver)
+		add(PlayPlayerRoleRusher, info.Rusher)
+		add(PlayPlayerRoleKicker, info.Kicker)
+		add(PlayPlayerRoleLongSnapper, info.LongSnapper)
+		add(PlayPlayerRoleHolder, info.Holder)
+		add(PlayPlayerRoleBlocker, info.BlockedBy)
+		add(PlayPlayerRoleRecovery, info.KickRecoveredBy)
+		add(PlayPlayerRoleFairCatch, info.FairCatchBy)
+		add(PlayPlayerRoleDowned, info.DownedBy)
+		add(PlayPlayerRolePassDefender, info.PassDefensedBy...)
+		add(PlayPlayerRoleInterceptor, info.InterceptedBy)
+		for _, c := range info.Carries {
+			switch c.Kind {
+			case PlayDescriptionCarryKindRun, PlayDescriptionCarryKindKneel:
+				add(PlayPlayerRoleRusher, c.Player)
+			case PlayDescriptionCarryKindReception:
+				add(PlayPlayerRoleTarget, c.Player)
+			case PlayDescriptionCarryKindSack:
+				add(PlayPlayerRoleSacked, c.Player)
+			case PlayDescriptionCarryKindReturn:
+				add(PlayPlayerRoleReturner, c.Player)
+			case PlayDescriptionCarryKindInterceptionReturn:
+				add(PlayPlayerRoleInterceptor, c.Player)
+			default:
+				add(PlayPlayerRoleBallCarrier, c.Player)
+			}
+			add(PlayPlayerRoleTackler, c.Tacklers...)
+		}
+		add(PlayPlayerRoleQuarterbackHit, info.QuarterbackHits...)
+		for _, f := range info.Fumbles {
+			add(PlayPlayerRoleFumbler, f.Player)
+			add(PlayPlayerRoleForcedFumble, f.ForcedBy)
+			add(PlayPlayerRoleRecovery, f.RecoveredBy)
+		}
+		add(PlayPlayerRoleInjured, info.Injured...)
+	}
+	return ret
+}
+
+// ResolvePlayPlayers finds every player mentioned in the play's description and resolves them
+// against the roster and play stats. The stats may include rows for other plays, which are
+// ignored. PlayDescriptionWithJerseyNumbers is used if it's available, and players are matched
+// on jersey number and name. Players whose jersey number doesn't match the roster are matched on
+// name alone, and players that aren't on the roster are matched against the play stats.
+func ResolvePlayPlayers(play *StatFilePlay, roster *RosterFile, stats []StatFilePlayStat) *PlayPlayers {
+	// With jersey numbers, requiring them avoids mistaking text like "No Play. Penalty" for a
+	// player.
+	description, tokenRegexp := play.PlayDescriptionWithJerseyNumbers, playPlayerNumberedTokenRegexp
+	if description == "" {
+		description, tokenRegexp = play.PlayDescription, playPlayerTokenRegexp
+	}
+
+	clubCodes := map[int]string{}
+	var rosterPlayers []RosterFilePlayer
+	if roster != nil {
+		if k := roster.GameKey; k != nil {
+			clubCodes[k.HomeClubKey] = k.HomeClubCode
+			clubCodes[k.VisitClubKey] = k.VisitClubCode
+		}
+		rosterPlayers = roster.Player
+	}
+	sameClub := func(a, b string) bool {
+		return a == "" || b == "" || CommonTeamAbbreviation(a) == CommonTeamAbbreviation(b)
+	}
+
+	var playStats []StatFilePlayStat
+	for _, stat := range stats {
+		if stat.PlayID == play.PlayID {
+			playStats = append(playStats, stat)
+		}
+	}
+
+	roles := playDescriptionRoles(ParsePlayDescription(description))
+
+	ret := &PlayPlayers{}
+	players := map[string]*PlayPlayer{}
+	for _, m := range tokenRegexp.FindAllStringSubmatchIndex(description, -1) {
+		var team, number string
+		if m[2] >= 0 {
+			team = description[m[2]:m[3]]
+		}
+		if m[4] >= 0 {
+			number = description[m[4]:m[5]]
+		}
+		name := description[m[6]:m[7]]
+		key := playPlayerKey(number, name)
+
+		penalized := strings.HasSuffix(strings.ToLower(description[:m[0]]), "penalty on ")
+		if p, ok := players[key]; ok {
+			if penalized && !p.HasRole(PlayPlayerRolePenalized) {
+				p.Roles = append(p.Roles, PlayPlayerRolePenalized)
+			}
+			continue
+		}
+
+		p := &PlayPlayer{
+			Token:        description[m[0]:m[1]],
+			Name:         name,
+			JerseyNumber: number,
+			Roles:        append([]PlayPlayerRole(nil), roles[key]...),
+		}
+		if team != "" {
+			p.Token = strings.TrimPrefix(p.Token, team+"-")
+		}
+		if penalized {
+			p.Roles = append(p.Roles, PlayPlayerRolePenalized)
+		}
+		players[key] = p
+
+		var candidates []RosterFilePlayer
+		for _, rp := range rosterPlayers {
+			if normalizePlayerName(rp.Name) == normalizePlayerName(name) && sameClub(team, clubCodes[rp.ClubKey]) {
+				candidates = append(candidates, rp)
+			}
+		}
+		if number != "" {
+			var numbered []RosterFilePlayer
+			for _, rp := range candidates {
+				if normalizeJerseyNumber(rp.JerseyNumber) == normalizeJerseyNumber(number) {
+					numbered = append(numbered, rp)
+				}
+			}
+			if len(numbered) > 0 {
+				candidates = numbered
+			}
+		}
+
+		// Narrow down multiple candidates using the players credited with stats on the play.
+		if len(candidates) > 1 {
+			var credited []RosterFilePlayer
+			for _, rp := range candidates {
+				for _, stat := range playStats {
+					if stat.PlayerID == rp.GSISPlayer_ID {
+						credited = append(credited, rp)
+						break
+					}
+				}
+			}
+			if len(credited) > 0 {
+				candidates = credited
+			}
+		}
+
+		switch len(candidates) {
+		case 0:
+			for _, stat := range playStats {
+				if stat.PlayerID != "" && normalizePlayerName(stat.PlayerName) == normalizePlayerName(name) && sameClub(team, stat.ClubCode) && (number == "" || normalizeJerseyNumber(stat.UniformNumber) == normalizeJerseyNumber(number)) {
+					p.GSISPlayerID = stat.PlayerID
+					p.ClubCode = stat.ClubCode
+					break
+				}
+			}
+			if p.GSISPlayerID == "" {
+				ret.Unmatched = append(ret.Unmatched, p)
+				continue
+			}
+		case 1:
+			p.GSISPlayerID = candidates[0].GSISPlayer_ID
+			p.ClubCode = clubCodes[candidates[0].ClubKey]
+			p.Position = candidates[0].Position
+		default:
+			p.Candidates = candidates
+			ret.Ambiguous = append(ret.Ambiguous, p)
+			continue
+		}
+		ret.Players = append(ret.Players, p)
+	}
+	return ret
+}
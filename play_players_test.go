@@ -0,0 +1,124 @@
+package gsis
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePlayPlayers(t *testing.T) {
+	buf, err := ioutil.ReadFile("testdata/signalr-stats.json")
+	require.NoError(t, err)
+	var stats StatFile
+	require.NoError(t, json.Unmarshal(buf, &stats))
+
+	buf, err = ioutil.ReadFile("testdata/Roster.xml")
+	require.NoError(t, err)
+	var roster RosterFile
+	require.NoError(t, xml.Unmarshal(buf, &roster))
+
+	plays := map[int]*StatFilePlay{}
+	for _, p := range stats.Play {
+		plays[int(p.PlayID)] = p
+	}
+
+	t.Run("Penalty", func(t *testing.T) {
+		players := ResolvePlayPlayers(plays[99], &roster, stats.PlayStat)
+		assert.Empty(t, players.Ambiguous)
+		assert.Empty(t, players.Unmatched)
+		assert.Equal(t, []*PlayPlayer{
+			{
+				Token:        "16-J.Goff",
+				Name:         "J.Goff",
+				JerseyNumber: "16",
+				GSISPlayerID: "00-0033106",
+				ClubCode:     "LA",
+				Position:     "QB",
+				Roles:        []PlayPlayerRole{PlayPlayerRolePasser},
+			},
+			{
+				Token:        "18-C.Kupp",
+				Name:         "C.Kupp",
+				JerseyNumber: "18",
+				GSISPlayerID: "00-0033908",
+				ClubCode:     "LA",
+				Position:     "WR",
+				Roles:        []PlayPlayerRole{PlayPlayerRoleTarget},
+			},
+			{
+				Token:        "58-R.Quinn",
+				Name:         "R.Quinn",
+				JerseyNumber: "58",
+				GSISPlayerID: "00-0027952",
+				ClubCode:     "DAL",
+				Position:     "DE",
+				Roles:        []PlayPlayerRole{PlayPlayerRolePenalized},
+			},
+		}, players.Players)
+	})
+
+	t.Run("Fumble", func(t *testing.T) {
+		players := ResolvePlayPlayers(plays[3717], &roster, stats.PlayStat)
+		assert.Empty(t, players.Ambiguous)
+		assert.Empty(t, players.Unmatched)
+		roles := map[string][]PlayPlayerRole{}
+		for _, p := range players.Players {
+			roles[p.Token] = p.Roles
+		}
+		assert.Equal(t, map[string][]PlayPlayerRole{
+			"16-J.Goff":    {PlayPlayerRolePasser},
+			"18-C.Kupp":    {PlayPlayerRoleTarget, PlayPlayerRoleTackler, PlayPlayerRoleFumbler},
+			"27-J.Lewis":   {PlayPlayerRoleTackler, PlayPlayerRoleForcedFumble},
+			"24-C.Awuzie":  {PlayPlayerRoleBallCarrier, PlayPlayerRoleRecovery},
+			"79-M.Bennett": {PlayPlayerRolePenalized},
+		}, roles)
+	})
+
+	t.Run("SameNameOnBothTeams", func(t *testing.T) {
+		players := ResolvePlayPlayers(plays[690], &roster, stats.PlayStat)
+		require.Len(t, players.Players, 2)
+		assert.Equal(t, "00-0031785", players.Players[1].GSISPlayerID)
+		assert.Equal(t, "LA", players.Players[1].ClubCode)
+		assert.Equal(t, []PlayPlayerRole{PlayPlayerRoleTackler}, players.Players[1].Roles)
+
+		// Without jersey numbers or stats, there's no way to tell them apart.
+		play := *plays[690]
+		play.PlayDescriptionWithJerseyNumbers = ""
+		players = ResolvePlayPlayers(&play, &roster, nil)
+		require.Len(t, players.Ambiguous, 1)
+		assert.Equal(t, "T.Hill", players.Ambiguous[0].Token)
+		assert.Len(t, players.Ambiguous[0].Candidates, 2)
+
+		// But the stats say who made the tackle.
+		players = ResolvePlayPlayers(&play, &roster, stats.PlayStat)
+		assert.Empty(t, players.Ambiguous)
+		require.Len(t, players.Players, 2)
+		assert.Equal(t, "00-0031785", players.Players[1].GSISPlayerID)
+	})
+
+	t.Run("NotOnRoster", func(t *testing.T) {
+		players := ResolvePlayPlayers(plays[99], &RosterFile{}, stats.PlayStat)
+		assert.Empty(t, players.Ambiguous)
+
+		// The play was wiped out, so only the penalty is credited in the stats.
+		require.Len(t, players.Players, 1)
+		assert.Equal(t, "58-R.Quinn", players.Players[0].Token)
+		assert.Equal(t, "00-0027952", players.Players[0].GSISPlayerID)
+		assert.Equal(t, "DAL", players.Players[0].ClubCode)
+		assert.Empty(t, players.Players[0].Position)
+		require.Len(t, players.Unmatched, 2)
+		assert.Equal(t, "16-J.Goff", players.Unmatched[0].Token)
+		assert.Equal(t, []PlayPlayerRole{PlayPlayerRolePasser}, players.Unmatched[0].Roles)
+		assert.Equal(t, "18-C.Kupp", players.Unmatched[1].Token)
+	})
+
+	for _, p := range stats.ActualPlays() {
+		players := ResolvePlayPlayers(p, &roster, stats.PlayStat)
+		assert.Empty(t, players.Ambiguous, p.PlayDescriptionWithJerseyNumbers)
+		assert.Empty(t, players.Unmatched, p.PlayDescriptionWithJerseyNumbers)
+	}
+}
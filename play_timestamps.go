@@ -0,0 +1,145 @@
+package gsis
+
+import (
+	"sort"
+	"time"
+)
+
+// Gaps between plays longer than this are only trusted if later plays agree with them.
+const maxUnconfirmedPlayTimeGap = 30 * time.Minute
+
+type PlayTimestamp struct {
+	Play *StatFilePlay
+
+	// The time of the snap in UTC.
+	Start time.Time
+
+	// The estimated end of the play, taken from the start of the next play. This is zero for the
+	// last play.
+	End time.Time
+
+	// True if the play had no time of day or one that was earlier than the play before it, and
+	// Start was estimated from the surrounding plays.
+	Estimated bool
+}
+
+// Returns a time in the middle of the game's local date, or false if the date isn't known.
+func (f *StatFile) gameDateReferenceTime() (time.Time, bool) {
+	if h := f.CumeStatHeader; h != nil && h.Game_Date != "" {
+		zone := time.FixedZone("", int(h.GMTOffset)*int(time.Hour/time.Second))
+		if date, err := time.ParseInLocation("01/02/2006", h.Game_Date, zone); err == nil {
+			return date.Add(12 * time.Hour), true
+		}
+	}
+	if c := f.CumulativeStatisticsFile; c != nil && !c.DateTimeStampUTC.IsZero() {
+		return c.DateTimeStampUTC, true
+	}
+	return time.Time{}, false
+}
+
+// PlayTimestamps returns the UTC start time of every play that hasn't been deleted. TimeOfDay is
+// resolved against the game date and the plays before it, so games that go past midnight UTC are
+// handled. Plays without a time of day, or with one that's out of order with the plays around it,
+// are given a time interpolated between their neighbors. It returns nil if the game date is
+// unknown or no plays have a time of day.
+func (f *StatFile) PlayTimestamps() []*PlayTimestamp {
+	reference, ok := f.gameDateReferenceTime()
+	if !ok {
+		return nil
+	}
+
+	var ret []*PlayTimestamp
+	var parsed []int
+	for _, p := range f.Play {
+		if p.PlayDeleted != 0 {
+			continue
+		}
+		ts := &PlayTimestamp{
+			Play:      p,
+			Estimated: true,
+		}
+		if p.TimeOfDay != "" {
+			if t, err := ParseTimeOfDay(p.TimeOfDay, reference); err == nil {
+				// Each play is resolved against the one before it, which keeps them on the
+				// right date when the game crosses midnight.
+				reference = t
+				ts.Start = t
+				parsed = append(parsed, len(ret))
+			}
+		}
+		ret = append(ret, ts)
+	}
+
+	// Times are sometimes corrected or entered late, so only the longest run of times that never
+	// go backwards is kept.
+	known := longestNonDecreasingTimes(ret, parsed)
+	if len(known) == 0 {
+		return nil
+	}
+
+	// A long gap before the last known time can't be confirmed by the plays after it, and is
+	// usually a play that was entered after the game.
+	for len(known) > 1 && ret[known[len(known)-1]].Start.Sub(ret[known[len(known)-2]].Start) > maxUnconfirmedPlayTimeGap {
+		known = known[:len(known)-1]
+	}
+	for _, i := range known {
+		ret[i].Estimated = false
+	}
+
+	// Fill in the rest, spacing them evenly between the known ones.
+	for i, ts := range ret {
+		if !ts.Estimated {
+			continue
+		}
+		next := 0
+		for next < len(known) && known[next] < i {
+			next++
+		}
+		switch {
+		case next == 0:
+			ts.Start = ret[known[0]].Start
+		case next == len(known):
+			ts.Start = ret[known[len(known)-1]].Start
+		default:
+			a, b := known[next-1], known[next]
+			start, end := ret[a].Start, ret[b].Start
+			ts.Start = start.Add(end.Sub(start) * time.Duration(i-a) / time.Duration(b-a))
+		}
+	}
+
+	for i := 0; i+1 < len(ret); i++ {
+		ret[i].End = ret[i+1].Start
+	}
+	return ret
+}
+
+// Returns the longest subsequence of the given indices whose start times never decrease.
+func longestNonDecreasingTimes(timestamps []*PlayTimestamp, indices []int) []int {
+	// tails[n] is the position in indices of the smallest possible last element of a subsequence
+	// of length n+1.
+	var tails []int
+	previous := make([]int, len(indices))
+	for i, index := range indices {
+		t := timestamps[index].Start
+		n := sort.Search(len(tails), func(n int) bool {
+			return timestamps[indices[tails[n]]].Start.After(t)
+		})
+		previous[i] = -1
+		if n > 0 {
+			previous[i] = tails[n-1]
+		}
+		if n == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[n] = i
+		}
+	}
+	if len(tails) == 0 {
+		return nil
+	}
+	ret := make([]int, len(tails))
+	for i, n := tails[len(tails)-1], len(tails)-1; n >= 0; i, n = previous[i], n-1 {
+		ret[n] = indices[i]
+	}
+	return ret
+}
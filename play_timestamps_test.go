@@ -0,0 +1,125 @@
+package gsis
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatFile_PlayTimestamps(t *testing.T) {
+	buf, err := ioutil.ReadFile("testdata/signalr-stats.json")
+	require.NoError(t, err)
+	var stats StatFile
+	require.NoError(t, json.Unmarshal(buf, &stats))
+
+	timestamps := stats.PlayTimestamps()
+	require.NotEmpty(t, timestamps)
+	for i, ts := range timestamps {
+		if ts.Play.PlayID == 132 {
+			assert.Equal(t, time.Date(2019, 12, 15, 21, 28, 43, 0, time.UTC), ts.Start)
+			assert.False(t, ts.Estimated)
+			assert.Equal(t, timestamps[i+1].Start, ts.End)
+		}
+	}
+	assert.True(t, timestamps[len(timestamps)-1].End.IsZero())
+}
+
+func TestStatFile_PlayTimestamps_Midnight(t *testing.T) {
+	play := func(id int, timeOfDay string) *StatFilePlay {
+		return &StatFilePlay{
+			PlayID:    StringInt(id),
+			TimeOfDay: timeOfDay,
+		}
+	}
+	stats := &StatFile{
+		CumeStatHeader: &StatFileCumeStatHeader{
+			Game_Date: "11/01/2018",
+			GMTOffset: -5,
+		},
+		Play: []*StatFilePlay{
+			play(1, "23:58:00"),
+			play(2, ""),
+			play(3, "00:01:00"),
+			// A time that goes backwards.
+			play(4, "23:50:00"),
+			play(5, "00:02:00"),
+			play(6, ""),
+		},
+	}
+	timestamps := stats.PlayTimestamps()
+	require.Len(t, timestamps, 6)
+
+	for i, expected := range []struct {
+		Start     time.Time
+		Estimated bool
+	}{
+		{time.Date(2018, 11, 1, 23, 58, 0, 0, time.UTC), false},
+		{time.Date(2018, 11, 1, 23, 59, 30, 0, time.UTC), true},
+		{time.Date(2018, 11, 2, 0, 1, 0, 0, time.UTC), false},
+		{time.Date(2018, 11, 2, 0, 1, 30, 0, time.UTC), true},
+		{time.Date(2018, 11, 2, 0, 2, 0, 0, time.UTC), false},
+		{time.Date(2018, 11, 2, 0, 2, 0, 0, time.UTC), true},
+	} {
+		assert.Equal(t, expected.Start, timestamps[i].Start, "play %v", i+1)
+		assert.Equal(t, expected.Estimated, timestamps[i].Estimated, "play %v", i+1)
+		if i+1 < len(timestamps) {
+			assert.Equal(t, timestamps[i+1].Start, timestamps[i].End)
+		}
+	}
+
+	assert.Nil(t, (&StatFile{Play: stats.Play}).PlayTimestamps())
+}
+
+func TestStatFile_PlayTimestamps_LateEntry(t *testing.T) {
+	buf, err := ioutil.ReadFile("testdata/games/2018110100/GSISGameStats.xml")
+	require.NoError(t, err)
+	var stats StatFile
+	require.NoError(t, xml.Unmarshal(buf, &stats))
+
+	timestamps := stats.PlayTimestamps()
+	require.NotEmpty(t, timestamps)
+
+	// The game kicked off on the night of November 1st, Eastern time.
+	assert.Equal(t, time.Date(2018, 11, 2, 0, 23, 6, 0, time.UTC), timestamps[0].Start)
+
+	// Near the end of the game, a punt's time of day is four hours after the play before it, and
+	// the plays after it have no times.
+	last := timestamps[len(timestamps)-1]
+	assert.True(t, last.Estimated)
+	assert.Equal(t, time.Date(2018, 11, 2, 3, 10, 14, 0, time.UTC), last.Start)
+	for _, ts := range timestamps {
+		if ts.Play.PlayID == 3679 {
+			assert.True(t, ts.Estimated)
+			assert.Equal(t, last.Start, ts.Start)
+		}
+	}
+}
+
+func TestStatFile_PlayTimestamps_Games(t *testing.T) {
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		timestamps := stats.PlayTimestamps()
+		if timestamps == nil {
+			return
+		}
+
+		plays := 0
+		for _, p := range stats.Play {
+			if p.PlayDeleted == 0 {
+				plays++
+			}
+		}
+		require.Len(t, timestamps, plays)
+
+		for i, ts := range timestamps {
+			if i > 0 {
+				assert.False(t, ts.Start.Before(timestamps[i-1].Start))
+			}
+			assert.True(t, ts.Start.Sub(timestamps[0].Start) < 8*time.Hour)
+		}
+	})
+}
@@ -0,0 +1,196 @@
+package gsis
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Who initiated a replay review.
+type ReviewInitiator string
+
+const (
+	// A coach's challenge.
+	ReviewInitiatorChallenge ReviewInitiator = "CHALLENGE"
+
+	// A review initiated by the replay official, such as for scoring plays and turnovers.
+	ReviewInitiatorBooth ReviewInitiator = "BOOTH"
+)
+
+type ReviewOutcome string
+
+const (
+	ReviewOutcomeUpheld   ReviewOutcome = "UPHELD"
+	ReviewOutcomeReversed ReviewOutcome = "REVERSED"
+)
+
+type ReplayReview struct {
+	Initiator ReviewInitiator
+
+	// For challenges, the team as it appears in the description, e.g. "Los Angeles Rams", and its
+	// club code. The club code is empty if the name isn't recognized.
+	TeamName string
+	ClubCode string
+
+	// What was reviewed, e.g. "pass completion" or "possible defensive pass interference".
+	Subject string
+
+	Outcome ReviewOutcome
+
+	// True if the ruling on the field was confirmed, rather than standing for lack of evidence to
+	// overturn it. GSIS doesn't always say which, so this may be false for upheld rulings.
+	Confirmed bool
+
+	// For challenges that failed, the number of the timeout charged to the team, and the game clock
+	// when it was charged if GSIS gives one.
+	TimeoutCharged bool
+	TimeoutNumber  int
+	TimeoutClock   string
+}
+
+// Successful returns true if the review was a challenge that reversed the ruling on the field.
+func (r *ReplayReview) Successful() bool {
+	return r.Initiator == ReviewInitiatorChallenge && r.Outcome == ReviewOutcomeReversed
+}
+
+var (
+	replayReviewRegexp        = regexp.MustCompile(`([A-Z][A-Za-z]*(?: [A-Z][A-Za-z]*)*) (reviewed|challenged) the (?:play for (possible .+?)|(.+?) ruling), and the play was (Upheld|REVERSED)`)
+	replayReviewRulingRegexp  = regexp.MustCompile(`^\.?\s*The ruling on the field (stands|was confirmed)`)
+	replayReviewTimeoutRegexp = regexp.MustCompile(`^\.?\s*\(Timeout #(\d)(?: at (\d*:\d\d))?\.?\)`)
+)
+
+// ParsePlayDescriptionReviews returns the replay reviews in a play description, in order.
+func ParsePlayDescriptionReviews(description string) []ReplayReview {
+	var ret []ReplayReview
+	for _, m := range replayReviewRegexp.FindAllStringSubmatchIndex(description, -1) {
+		group := func(n int) string {
+			if m[2*n] < 0 {
+				return ""
+			}
+			return description[m[2*n]:m[2*n+1]]
+		}
+		review := ReplayReview{
+			Initiator: ReviewInitiatorChallenge,
+			Subject:   group(3) + group(4),
+			Outcome:   ReviewOutcomeUpheld,
+		}
+		if who := group(1); who == "The Replay Official" || group(2) == "reviewed" {
+			review.Initiator = ReviewInitiatorBooth
+		} else {
+			review.TeamName = who
+			review.ClubCode, _ = TeamNameClubCode(who)
+		}
+		if group(5) == "REVERSED" {
+			review.Outcome = ReviewOutcomeReversed
+		}
+
+		rest := description[m[1]:]
+		if r := replayReviewRulingRegexp.FindStringSubmatchIndex(rest); r != nil {
+			review.Confirmed = rest[r[2]:r[3]] == "was confirmed"
+			rest = rest[r[1]:]
+		}
+		if t := replayReviewTimeoutRegexp.FindStringSubmatch(rest); t != nil && review.Initiator == ReviewInitiatorChallenge {
+			review.TimeoutCharged = true
+			review.TimeoutNumber, _ = strconv.Atoi(t[1])
+			review.TimeoutClock = strings.TrimLeft(t[2], "0")
+		}
+		ret = append(ret, review)
+	}
+	return ret
+}
+
+// A replay review along with the play it was for.
+type PlayReview struct {
+	PlayID StringInt
+	Play   *StatFilePlay
+
+	ReplayReview
+}
+
+// Reviews returns the replay reviews in the game, in play order. Deleted plays are ignored.
+func (f *StatFile) Reviews() []*PlayReview {
+	var ret []*PlayReview
+	for _, p := range f.Play {
+		if p.PlayDeleted != 0 {
+			continue
+		}
+		for _, review := range ParsePlayDescriptionReviews(p.PlayDescription) {
+			ret = append(ret, &PlayReview{
+				PlayID:       p.PlayID,
+				Play:         p,
+				ReplayReview: review,
+			})
+		}
+	}
+	return ret
+}
+
+// A team's challenges in a game.
+type TeamChallengeSummary struct {
+	ClubCode string
+
+	Challenges      int
+	Successful      int
+	TimeoutsCharged int
+
+	// The team's challenges, in play order.
+	Reviews []*PlayReview
+}
+
+type GameReviewSummary struct {
+	// Every review in the game, in play order.
+	Reviews []*PlayReview
+
+	BoothReviews   int
+	BoothReversals int
+
+	// Challenges by team, including a summary for each of the home and visiting teams even if they
+	// didn't challenge. Challenges by unrecognized teams are keyed by the name in the description.
+	Teams map[string]*TeamChallengeSummary
+}
+
+// ReviewSummary summarizes the game's replay reviews and each team's challenges.
+func (f *StatFile) ReviewSummary() *GameReviewSummary {
+	ret := &GameReviewSummary{
+		Reviews: f.Reviews(),
+		Teams:   map[string]*TeamChallengeSummary{},
+	}
+	team := func(clubCode string) *TeamChallengeSummary {
+		t, ok := ret.Teams[clubCode]
+		if !ok {
+			t = &TeamChallengeSummary{
+				ClubCode: clubCode,
+			}
+			ret.Teams[clubCode] = t
+		}
+		return t
+	}
+	if h := f.CumeStatHeader; h != nil {
+		team(h.HomeClubCode)
+		team(h.VisitorClubCode)
+	}
+	for _, review := range ret.Reviews {
+		switch review.Initiator {
+		case ReviewInitiatorBooth:
+			ret.BoothReviews++
+			if review.Outcome == ReviewOutcomeReversed {
+				ret.BoothReversals++
+			}
+		case ReviewInitiatorChallenge:
+			key := review.ClubCode
+			if key == "" {
+				key = review.TeamName
+			}
+			t := team(key)
+			t.Challenges++
+			if review.Successful() {
+				t.Successful++
+			}
+			if review.TimeoutCharged {
+				t.TimeoutsCharged++
+			}
+			t.Reviews = append(t.Reviews, review)
+		}
+	}
+	return ret
+}
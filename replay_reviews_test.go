@@ -0,0 +1,100 @@
+package gsis
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlayDescriptionReviews(t *testing.T) {
+	for desc, expected := range map[string][]ReplayReview{
+		"(13:26) J.Goff up the middle to ARZ 15 for no gain (R.Nkemdiche; H.Reddick).\nLos Angeles Rams challenged the short of the line to gain ruling, and the play was Upheld. The ruling on the field stands. (Timeout #1 at 13:10.)": {{
+			Initiator:      ReviewInitiatorChallenge,
+			TeamName:       "Los Angeles Rams",
+			ClubCode:       "LA",
+			Subject:        "short of the line to gain",
+			Outcome:        ReviewOutcomeUpheld,
+			TimeoutCharged: true,
+			TimeoutNumber:  1,
+			TimeoutClock:   "13:10",
+		}},
+		"(6:34) (Shotgun) L.Jackson left tackle to BLT 43 for 3 yards (C.Sensabaugh). Official measurement. Pittsburgh challenged the first down ruling, and the play was Upheld. The ruling on the field was confirmed. (Timeout #2 at 00:21.)": {{
+			Initiator:      ReviewInitiatorChallenge,
+			TeamName:       "Pittsburgh",
+			ClubCode:       "PIT",
+			Subject:        "first down",
+			Outcome:        ReviewOutcomeUpheld,
+			Confirmed:      true,
+			TimeoutCharged: true,
+			TimeoutNumber:  2,
+			TimeoutClock:   ":21",
+		}},
+		"(12:07) (No Huddle, Shotgun) C.Wentz pass deep left to Z.Ertz pushed ob at DAL 24 for 37 yards (B.Jones).\nDallas challenged the pass completion ruling, and the play was REVERSED.\n(No Huddle, Shotgun) C.Wentz pass incomplete deep left to Z.Ertz (B.Jones).": {{
+			Initiator: ReviewInitiatorChallenge,
+			TeamName:  "Dallas",
+			ClubCode:  "DAL",
+			Subject:   "pass completion",
+			Outcome:   ReviewOutcomeReversed,
+		}},
+		"(1:01) (Shotgun) J.Winston pass incomplete deep right to M.Evans (R.Sherman) [N.Bosa].\nThe Replay Official reviewed the play for possible defensive pass interference, and the play was Upheld. The ruling on the field stands.": {{
+			Initiator: ReviewInitiatorBooth,
+			Subject:   "possible defensive pass interference",
+			Outcome:   ReviewOutcomeUpheld,
+		}},
+		"(12:20) J.Wilson right end for 9 yards, TOUCHDOWN.\nThe Replay Official reviewed the runner broke the plane ruling, and the play was REVERSED.\nJ.Wilson right end to TB 1 for 8 yards (B.Grimes).": {{
+			Initiator: ReviewInitiatorBooth,
+			Subject:   "runner broke the plane",
+			Outcome:   ReviewOutcomeReversed,
+		}},
+		"(15:00) J.Myers kicks 65 yards from SEA 35 to end zone, Touchback.": nil,
+	} {
+		assert.Equal(t, expected, ParsePlayDescriptionReviews(desc), desc)
+	}
+}
+
+func TestStatFile_ReviewSummary(t *testing.T) {
+	buf, err := ioutil.ReadFile("testdata/games/2018091609/GSISGameStats.xml")
+	require.NoError(t, err)
+	var stats StatFile
+	require.NoError(t, xml.Unmarshal(buf, &stats))
+
+	summary := stats.ReviewSummary()
+	require.Len(t, summary.Reviews, 1)
+	assert.EqualValues(t, 2803, summary.Reviews[0].PlayID)
+	assert.Equal(t, summary.Reviews[0].PlayID, summary.Reviews[0].Play.PlayID)
+	assert.Equal(t, 0, summary.BoothReviews)
+
+	require.Len(t, summary.Teams, 2)
+	rams := summary.Teams["LA"]
+	require.NotNil(t, rams)
+	assert.Equal(t, 1, rams.Challenges)
+	assert.Equal(t, 0, rams.Successful)
+	assert.Equal(t, 1, rams.TimeoutsCharged)
+	assert.Equal(t, summary.Reviews, rams.Reviews)
+	assert.Equal(t, 0, summary.Teams["ARZ"].Challenges)
+}
+
+func TestStatFile_Reviews_Games(t *testing.T) {
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		reviews := map[StringInt]int{}
+		for _, review := range stats.Reviews() {
+			reviews[review.PlayID]++
+			if review.Initiator == ReviewInitiatorChallenge {
+				assert.NotEmpty(t, review.ClubCode, review.TeamName)
+
+				// Teams are charged a timeout for each failed challenge.
+				assert.Equal(t, !review.Successful(), review.TimeoutCharged, review.Play.PlayDescription)
+			}
+		}
+		for _, p := range stats.Play {
+			if p.PlayDeleted == 0 {
+				n := strings.Count(p.PlayDescription, " challenged the ") + strings.Count(p.PlayDescription, " reviewed the ")
+				assert.Equal(t, n, reviews[p.PlayID], p.PlayDescription)
+			}
+		}
+	})
+}
@@ -0,0 +1,344 @@
+package gsis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A game as described by the SignalR schedulehub, either in response to RegisterForSchedule or in a
+// push. The hub uses the same conventions as the stat file's CumeStatHeader.
+type ScheduleHubGame struct {
+	GameKey         StringInt
+	Season          StringInt
+	SeasonType      string
+	Week            StringInt
+	GameDate        string
+	HomeClubCode    string
+	VisitorClubCode string
+	HomeScore       StringInt
+	VisitorScore    StringInt
+
+	// For example, "Pregame", "1", "Halftime", "Final", or "final overtime".
+	Phase string
+
+	// For example, "1", "HALFTIME", "OVERTIME", or "END OF GAME".
+	Quarter string
+
+	// Time remaining in the quarter.
+	GameClock GameTime
+
+	// Sometimes wrapped in an extra set of quotes, e.g. `"DAL"`.
+	PossessionTeam string
+}
+
+// ParseScheduleHubGames parses the games from a schedulehub payload. Payloads may be a single game,
+// an array of games, or an object containing an array of games.
+func ParseScheduleHubGames(data json.RawMessage) ([]*ScheduleHubGame, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	switch data[0] {
+	case '[':
+		var ret []*ScheduleHubGame
+		if err := json.Unmarshal(data, &ret); err != nil {
+			return nil, fmt.Errorf("error unmarshaling schedule games: %w", err)
+		}
+		return ret, nil
+	case '{':
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("error unmarshaling schedule payload: %w", err)
+		}
+		if _, ok := fields["GameKey"]; ok {
+			var game ScheduleHubGame
+			if err := json.Unmarshal(data, &game); err != nil {
+				return nil, fmt.Errorf("error unmarshaling schedule game: %w", err)
+			}
+			return []*ScheduleHubGame{&game}, nil
+		}
+		var ret []*ScheduleHubGame
+		for _, v := range fields {
+			if v := bytes.TrimSpace(v); len(v) > 0 && v[0] == '[' {
+				games, err := ParseScheduleHubGames(v)
+				if err != nil {
+					return nil, err
+				}
+				ret = append(ret, games...)
+			}
+		}
+		return ret, nil
+	case '"':
+		// Like the play feed, some payloads are JSON encoded into a JSON string.
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, fmt.Errorf("error unmarshaling schedule payload: %w", err)
+		}
+		return ParseScheduleHubGames(json.RawMessage(s))
+	}
+	return nil, fmt.Errorf("unexpected schedule payload: %.20s", data)
+}
+
+type ScoreboardGameStatus string
+
+const (
+	ScoreboardGameStatusPregame    ScoreboardGameStatus = "PREGAME"
+	ScoreboardGameStatusInProgress ScoreboardGameStatus = "IN_PROGRESS"
+	ScoreboardGameStatusHalftime   ScoreboardGameStatus = "HALFTIME"
+	ScoreboardGameStatusFinal      ScoreboardGameStatus = "FINAL"
+)
+
+// The live state of a game on a Scoreboard.
+type ScoreboardGame struct {
+	GameKey         int
+	HomeClubCode    string
+	VisitorClubCode string
+	HomeScore       int
+	VisitorScore    int
+
+	// 1 through 4, then 5 and up for overtime periods. Zero before the game starts.
+	Quarter int
+
+	// Time remaining in the quarter.
+	Clock time.Duration
+
+	PossessionTeam string
+	Status         ScoreboardGameStatus
+}
+
+// NewScoreboardGame converts a schedulehub game to its scoreboard representation.
+func NewScoreboardGame(game *ScheduleHubGame) ScoreboardGame {
+	ret := ScoreboardGame{
+		GameKey:         int(game.GameKey),
+		HomeClubCode:    game.HomeClubCode,
+		VisitorClubCode: game.VisitorClubCode,
+		HomeScore:       int(game.HomeScore),
+		VisitorScore:    int(game.VisitorScore),
+		Clock:           game.GameClock.Duration(),
+		PossessionTeam:  strings.Trim(game.PossessionTeam, `"`),
+	}
+
+	phase := strings.ToLower(strings.TrimSpace(game.Phase))
+	switch {
+	case phase == "" || phase == "pregame":
+		ret.Status = ScoreboardGameStatusPregame
+	case phase == "halftime":
+		ret.Status = ScoreboardGameStatusHalftime
+	case strings.HasPrefix(phase, "final"):
+		ret.Status = ScoreboardGameStatusFinal
+	default:
+		ret.Status = ScoreboardGameStatusInProgress
+	}
+
+	if n, err := strconv.Atoi(strings.TrimSpace(game.Quarter)); err == nil {
+		ret.Quarter = n
+	} else if n, err := strconv.Atoi(phase); err == nil {
+		ret.Quarter = n
+	} else if strings.EqualFold(game.Quarter, "OVERTIME") || phase == "overtime" || phase == "final overtime" {
+		ret.Quarter = 5
+	} else if ret.Status == ScoreboardGameStatusHalftime {
+		ret.Quarter = 2
+	}
+	return ret
+}
+
+// ScoreboardChange is a set of flags describing what changed about a game.
+type ScoreboardChange int
+
+const (
+	ScoreboardChangeScore ScoreboardChange = 1 << iota
+	ScoreboardChangeQuarter
+	ScoreboardChangeClock
+	ScoreboardChangePossession
+	ScoreboardChangeStatus
+)
+
+func (c ScoreboardChange) Has(flag ScoreboardChange) bool {
+	return c&flag != 0
+}
+
+// An event emitted when a game on a Scoreboard changes.
+type ScoreboardEvent struct {
+	// Nil if this is the first time the game has been seen.
+	Previous *ScoreboardGame
+	Current  ScoreboardGame
+	Changes  ScoreboardChange
+}
+
+// Scoreboard keeps the latest state of every game in a week. It is safe for concurrent use.
+type Scoreboard struct {
+	mutex sync.Mutex
+	games map[int]ScoreboardGame
+}
+
+func NewScoreboard() *Scoreboard {
+	return &Scoreboard{
+		games: make(map[int]ScoreboardGame),
+	}
+}
+
+// Update applies a schedulehub game to the scoreboard. It returns nil if nothing changed. If the
+// game doesn't have a numeric quarter, such as "END OF GAME", the previous quarter is kept.
+func (s *Scoreboard) Update(game *ScheduleHubGame) *ScoreboardEvent {
+	current := NewScoreboardGame(game)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, ok := s.games[current.GameKey]
+	if ok && current.Quarter == 0 {
+		current.Quarter = previous.Quarter
+	}
+	s.games[current.GameKey] = current
+	if !ok {
+		return &ScoreboardEvent{
+			Current: current,
+			Changes: ScoreboardChangeScore | ScoreboardChangeQuarter | ScoreboardChangeClock | ScoreboardChangePossession | ScoreboardChangeStatus,
+		}
+	}
+
+	var changes ScoreboardChange
+	if previous.HomeScore != current.HomeScore || previous.VisitorScore != current.VisitorScore {
+		changes |= ScoreboardChangeScore
+	}
+	if previous.Quarter != current.Quarter {
+		changes |= ScoreboardChangeQuarter
+	}
+	if previous.Clock != current.Clock {
+		changes |= ScoreboardChangeClock
+	}
+	if previous.PossessionTeam != current.PossessionTeam {
+		changes |= ScoreboardChangePossession
+	}
+	if previous.Status != current.Status {
+		changes |= ScoreboardChangeStatus
+	}
+	if changes == 0 {
+		return nil
+	}
+	return &ScoreboardEvent{
+		Previous: &previous,
+		Current:  current,
+		Changes:  changes,
+	}
+}
+
+// Game returns the latest state of a game.
+func (s *Scoreboard) Game(gameKey int) (ScoreboardGame, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	game, ok := s.games[gameKey]
+	return game, ok
+}
+
+// Games returns the latest state of every game, ordered by game key.
+func (s *Scoreboard) Games() []ScoreboardGame {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	ret := make([]ScoreboardGame, 0, len(s.games))
+	for _, game := range s.games {
+		ret = append(ret, game)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].GameKey < ret[j].GameKey
+	})
+	return ret
+}
+
+// WatchSchedule registers for a schedule week and keeps a scoreboard of its games up to date until
+// the context is done or the connection is lost, at which point the event channel is closed. An
+// event is emitted for every game when it is first seen and whenever it changes.
+func (c *SignalRClient) WatchSchedule(ctx context.Context, season int, seasonType string, week int) (*Scoreboard, <-chan *ScoreboardEvent, error) {
+	subscriptions, err := c.Subscriptions()
+	if err != nil {
+		return nil, nil, fmt.Errorf("connection error: %w", err)
+	}
+
+	// Subscribe before registering so that no pushes are missed.
+	messages, unsubscribe := subscriptions.Connection().SubscribeHubMessages("schedulehub", 100)
+
+	resp, err := subscriptions.RegisterForSchedule(ctx, season, seasonType, week)
+	if err != nil {
+		unsubscribe()
+		return nil, nil, fmt.Errorf("error registering for schedule: %w", err)
+	}
+
+	initialGames, err := ParseScheduleHubGames(resp)
+	if err != nil {
+		c.Logger.Warn(fmt.Errorf("error parsing schedule: %w", err))
+	}
+
+	scoreboard := NewScoreboard()
+	events := make(chan *ScoreboardEvent, 100)
+
+	inWeek := func(game *ScheduleHubGame) bool {
+		// Pushes for other weeks registered on the same connection are delivered to every
+		// subscriber.
+		return (game.Season == 0 || int(game.Season) == season) &&
+			(game.SeasonType == "" || strings.EqualFold(game.SeasonType, seasonType)) &&
+			(game.Week == 0 || int(game.Week) == week)
+	}
+
+	go func() {
+		defer close(events)
+		defer func() {
+			unsubscribe()
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := subscriptions.UnregisterForSchedule(ctx, season, seasonType, week); err != nil && err != ErrSignalRConnectionClosed {
+				c.Logger.Warn(fmt.Errorf("error unregistering for schedule: %w", err))
+			}
+		}()
+
+		emit := func(games []*ScheduleHubGame) bool {
+			for _, game := range games {
+				if !inWeek(game) {
+					continue
+				}
+				if event := scoreboard.Update(game); event != nil {
+					select {
+					case events <- event:
+					case <-ctx.Done():
+						return false
+					}
+				}
+			}
+			return true
+		}
+
+		if !emit(initialGames) {
+			return
+		}
+
+		for {
+			select {
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				for _, arg := range msg.A {
+					games, err := ParseScheduleHubGames(arg)
+					if err != nil {
+						c.Logger.Warn(fmt.Errorf("error parsing schedulehub %v message: %w", msg.M, err))
+						continue
+					}
+					if !emit(games) {
+						return
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return scoreboard, events, nil
+}
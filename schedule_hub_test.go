@@ -0,0 +1,173 @@
+package gsis
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduleHubGames(t *testing.T) {
+	for input, expected := range map[string][]int{
+		`null`:                                 nil,
+		`{"GameKey":"58155"}`:                  {58155},
+		`[{"GameKey":"58155"},{"GameKey":1}]`:  {58155, 1},
+		`{"Games":[{"GameKey":"58155"}]}`:      {58155},
+		`"[{\"GameKey\":\"58155\"}]"`:          {58155},
+		`{"Season":"2019","Games":[],"X":"y"}`: nil,
+	} {
+		games, err := ParseScheduleHubGames(json.RawMessage(input))
+		require.NoError(t, err, input)
+		var gameKeys []int
+		for _, game := range games {
+			gameKeys = append(gameKeys, int(game.GameKey))
+		}
+		assert.Equal(t, expected, gameKeys, input)
+	}
+
+	_, err := ParseScheduleHubGames(json.RawMessage(`42`))
+	assert.Error(t, err)
+}
+
+func TestScoreboard(t *testing.T) {
+	scoreboard := NewScoreboard()
+
+	var game ScheduleHubGame
+	require.NoError(t, json.Unmarshal([]byte(`{"GameKey":"58155","HomeClubCode":"SEA","VisitorClubCode":"SF","Phase":"Pregame"}`), &game))
+	event := scoreboard.Update(&game)
+	require.NotNil(t, event)
+	assert.Nil(t, event.Previous)
+	assert.Equal(t, ScoreboardGameStatusPregame, event.Current.Status)
+
+	assert.Nil(t, scoreboard.Update(&game))
+
+	require.NoError(t, json.Unmarshal([]byte(`{"GameKey":"58155","HomeClubCode":"SEA","VisitorClubCode":"SF","Phase":"2","Quarter":"2","GameClock":"01:52","HomeScore":"7","VisitorScore":"3","PossessionTeam":"\"SF\""}`), &game))
+	event = scoreboard.Update(&game)
+	require.NotNil(t, event)
+	require.NotNil(t, event.Previous)
+	assert.Equal(t, ScoreboardGameStatusPregame, event.Previous.Status)
+	assert.Equal(t, ScoreboardGame{
+		GameKey:         58155,
+		HomeClubCode:    "SEA",
+		VisitorClubCode: "SF",
+		HomeScore:       7,
+		VisitorScore:    3,
+		Quarter:         2,
+		Clock:           time.Minute + 52*time.Second,
+		PossessionTeam:  "SF",
+		Status:          ScoreboardGameStatusInProgress,
+	}, event.Current)
+	assert.True(t, event.Changes.Has(ScoreboardChangeScore))
+	assert.True(t, event.Changes.Has(ScoreboardChangeStatus))
+
+	require.NoError(t, json.Unmarshal([]byte(`{"GameKey":"58155","Phase":"final overtime","Quarter":"END OF GAME","HomeScore":"7","VisitorScore":"3"}`), &game))
+	event = scoreboard.Update(&game)
+	require.NotNil(t, event)
+	assert.Equal(t, ScoreboardGameStatusFinal, event.Current.Status)
+	assert.Equal(t, 5, event.Current.Quarter)
+	assert.True(t, event.Changes.Has(ScoreboardChangeQuarter))
+	assert.True(t, event.Changes.Has(ScoreboardChangeStatus))
+	assert.False(t, event.Changes.Has(ScoreboardChangeScore))
+
+	// Games that end in regulation keep their last quarter.
+	require.NoError(t, json.Unmarshal([]byte(`{"GameKey":"58154","HomeClubCode":"LA","VisitorClubCode":"ARZ","Phase":"4","Quarter":"4","GameClock":"00:03","HomeScore":"31","VisitorScore":"24"}`), &game))
+	require.NotNil(t, scoreboard.Update(&game))
+	require.NoError(t, json.Unmarshal([]byte(`{"GameKey":"58154","Phase":"FINAL","Quarter":"END OF GAME","GameClock":"00:03","HomeScore":"31","VisitorScore":"24"}`), &game))
+	event = scoreboard.Update(&game)
+	require.NotNil(t, event)
+	assert.Equal(t, ScoreboardGameStatusFinal, event.Current.Status)
+	assert.Equal(t, 4, event.Current.Quarter)
+	assert.Equal(t, ScoreboardChangeStatus, event.Changes)
+
+	assert.Len(t, scoreboard.Games(), 2)
+}
+
+func TestSignalRClient_WatchSchedule(t *testing.T) {
+	unregistered := make(chan struct{})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/negotiate" {
+			w.Write([]byte(`{"Url":"/","ConnectionToken":"token","TryWebSockets":true}`))
+		} else if r.URL.Path == "/connect" {
+			conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			for {
+				_, p, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				var msg SignalRClientMessage
+				require.NoError(t, json.Unmarshal(p, &msg))
+
+				switch msg.M {
+				case "RegisterForSchedule":
+					require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"I":"`+strconv.Itoa(msg.I)+`","R":[
+						{"GameKey":"58155","Season":"2019","SeasonType":"REG","Week":"17","HomeClubCode":"SEA","VisitorClubCode":"SF","Phase":"Pregame"},
+						{"GameKey":"58154","Season":"2019","SeasonType":"REG","Week":"17","HomeClubCode":"LA","VisitorClubCode":"ARZ","Phase":"4","Quarter":"4","GameClock":"02:00","HomeScore":"24","VisitorScore":"17"}
+					]}`)))
+					require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"C":"d-1","M":[
+						{"H":"ScheduleHub","M":"updateGame","A":[{"GameKey":"58154","Season":"2019","SeasonType":"REG","Week":"17","HomeClubCode":"LA","VisitorClubCode":"ARZ","Phase":"4","Quarter":"4","GameClock":"01:45","HomeScore":"31","VisitorScore":"17"}]},
+						{"H":"ScheduleHub","M":"updateGame","A":[{"GameKey":"57000","Season":"2018","SeasonType":"REG","Week":"17"}]}
+					]}`)))
+				case "UnregisterForSchedule":
+					require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"I":"`+strconv.Itoa(msg.I)+`"}`)))
+					close(unregistered)
+				}
+			}
+		} else {
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	c := &SignalRClient{
+		URL:            ts.URL,
+		ConnectionData: `[{"name":"gamestatshub"},{"name":"schedulehub"}]`,
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scoreboard, events, err := c.WatchSchedule(ctx, 2019, "REG", 17)
+	require.NoError(t, err)
+
+	var received []*ScoreboardEvent
+	for len(received) < 3 {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for events")
+		}
+	}
+
+	assert.Nil(t, received[0].Previous)
+	assert.Nil(t, received[1].Previous)
+	assert.Equal(t, 58154, received[2].Current.GameKey)
+	assert.Equal(t, ScoreboardChangeScore|ScoreboardChangeClock, received[2].Changes)
+
+	game, ok := scoreboard.Game(58154)
+	require.True(t, ok)
+	assert.Equal(t, 31, game.HomeScore)
+	_, ok = scoreboard.Game(57000)
+	assert.False(t, ok)
+
+	cancel()
+	for range events {
+	}
+	select {
+	case <-unregistered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for unregistration")
+	}
+}
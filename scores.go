@@ -0,0 +1,262 @@
+package gsis
+
+import (
+	"strings"
+)
+
+type ScoreType string
+
+const (
+	ScoreTypeTouchdown         ScoreType = "TOUCHDOWN"
+	ScoreTypeFieldGoal         ScoreType = "FIELD_GOAL"
+	ScoreTypeSafety            ScoreType = "SAFETY"
+	ScoreTypeDefensiveTwoPoint ScoreType = "DEFENSIVE_TWO_POINT"
+)
+
+// The outcome of the try after a touchdown.
+type PATResult string
+
+const (
+	PATResultNone           PATResult = ""
+	PATResultKickGood       PATResult = "KICK_GOOD"
+	PATResultKickFailed     PATResult = "KICK_FAILED"
+	PATResultTwoPointGood   PATResult = "TWO_POINT_GOOD"
+	PATResultTwoPointFailed PATResult = "TWO_POINT_FAILED"
+)
+
+// Points returns the points the try was worth to the offense.
+func (r PATResult) Points() int {
+	switch r {
+	case PATResultKickGood:
+		return 1
+	case PATResultTwoPointGood:
+		return 2
+	}
+	return 0
+}
+
+type Score struct {
+	Type     ScoreType
+	ClubCode string
+
+	// The points scored, including the try.
+	Points int
+
+	Play *StatFilePlay
+
+	// The player credited with the score, and the passer for passing touchdowns. Safeties are
+	// sometimes credited to a team rather than a player.
+	Scorer *StatFilePlayStat
+	Passer *StatFilePlayStat
+
+	// The try after a touchdown, if there was one.
+	PATPlay   *StatFilePlay
+	PATResult PATResult
+
+	// The score after this one, including the try.
+	HomeScore    int
+	VisitorScore int
+
+	// The event from the scoring summary, or nil if the score was found in the plays.
+	Event *StatFileScoringSummaryEvent
+}
+
+func scoreTypeForEvent(event *StatFileScoringSummaryEvent) ScoreType {
+	switch event.ScoreType {
+	case "T":
+		return ScoreTypeTouchdown
+	case "F":
+		return ScoreTypeFieldGoal
+	case "S":
+		return ScoreTypeSafety
+	}
+	if strings.Contains(strings.ToLower(event.PlayDescription), "defensive two point") {
+		return ScoreTypeDefensiveTwoPoint
+	}
+	return ""
+}
+
+func (t ScoreType) points() int {
+	switch t {
+	case ScoreTypeTouchdown:
+		return 6
+	case ScoreTypeFieldGoal:
+		return 3
+	case ScoreTypeSafety, ScoreTypeDefensiveTwoPoint:
+		return 2
+	}
+	return 0
+}
+
+// Returns the result of a try from its stats, falling back to its description if there are none.
+func patResult(play *StatFilePlay, stats []StatFilePlayStat) PATResult {
+	ret := PATResultNone
+	for _, stat := range stats {
+		switch stat.StatID {
+		case StatID2PointRushGood, StatID2PointPassGood, StatID2PointPassReceptionGood:
+			return PATResultTwoPointGood
+		case StatID2PointRushFailed, StatID2PointPassFailed, StatID2PointPassReceptionFailed:
+			ret = PATResultTwoPointFailed
+		case StatIDExtraPointGood:
+			if ret == PATResultNone {
+				ret = PATResultKickGood
+			}
+		case StatIDExtraPointFailed, StatIDExtraPointBlocked, StatIDExtraPointAborted:
+			if ret == PATResultNone {
+				ret = PATResultKickFailed
+			}
+		}
+	}
+	if ret != PATResultNone || play == nil {
+		return ret
+	}
+
+	info := ParsePlayDescription(play.PlayDescription)
+	switch {
+	case info.TwoPointAttempt && info.TwoPointSucceeded:
+		return PATResultTwoPointGood
+	case info.TwoPointAttempt:
+		return PATResultTwoPointFailed
+	case info.Type == PlayDescriptionTypeExtraPoint && info.KickResult == KickResultGood:
+		return PATResultKickGood
+	case info.Type == PlayDescriptionTypeExtraPoint:
+		return PATResultKickFailed
+	}
+	return PATResultNone
+}
+
+// Finds the players credited with a score.
+func (s *Score) setScorer(stats []StatFilePlayStat) {
+	for i := range stats {
+		stat := &stats[i]
+		switch s.Type {
+		case ScoreTypeTouchdown:
+			switch {
+			case stat.StatID == StatIDPassingYardsTD || stat.StatID == StatIDPassingYardsTDNoPass:
+				if s.Passer == nil {
+					s.Passer = stat
+				}
+				continue
+			case !stat.StatID.IsTouchdown():
+				continue
+			}
+		case ScoreTypeFieldGoal:
+			if stat.StatID != StatIDFieldGoalYards {
+				continue
+			}
+		case ScoreTypeSafety:
+			if stat.StatID != StatIDSafetyDefense && stat.StatID != StatIDHalfSafetyDefense {
+				continue
+			}
+		case ScoreTypeDefensiveTwoPoint:
+			if stat.StatID != StatIDDefensive2PointConversions && stat.StatID != StatID2PointReturnGood {
+				continue
+			}
+		}
+		if s.Scorer == nil {
+			s.Scorer = stat
+		}
+	}
+}
+
+// Scores returns each score in the game in order, joined to its play, try, and the players
+// credited with it. If the file has no scoring summary, as is the case for some SignalR payloads,
+// the scores are found using the play stats instead.
+func (f *StatFile) Scores() []*Score {
+	var homeClubCode, visitorClubCode string
+	if f.CumeStatHeader != nil {
+		homeClubCode = f.CumeStatHeader.HomeClubCode
+		visitorClubCode = f.CumeStatHeader.VisitorClubCode
+	}
+
+	plays := map[StringInt]*StatFilePlay{}
+	for _, p := range f.Play {
+		if p.PlayDeleted == 0 {
+			plays[p.PlayID] = p
+		}
+	}
+	stats := map[StringInt][]StatFilePlayStat{}
+	for _, stat := range f.PlayStat {
+		stats[stat.PlayID] = append(stats[stat.PlayID], stat)
+	}
+
+	if len(f.ScoringSummary) > 0 {
+		ret := make([]*Score, 0, len(f.ScoringSummary))
+		for _, event := range f.ScoringSummary {
+			s := &Score{
+				Type:         scoreTypeForEvent(event),
+				ClubCode:     event.ScoringClubCode,
+				Play:         plays[event.ScoringPlayID],
+				HomeScore:    int(event.HomeScore),
+				VisitorScore: int(event.VisitorScore),
+				Event:        event,
+			}
+			s.setScorer(stats[event.ScoringPlayID])
+			if event.PATPlayID != 0 {
+				s.PATPlay = plays[event.PATPlayID]
+				s.PATResult = patResult(s.PATPlay, stats[event.PATPlayID])
+			}
+			s.Points = s.Type.points() + s.PATResult.Points()
+			ret = append(ret, s)
+		}
+		return ret
+	}
+
+	var ret []*Score
+	homeScore, visitorScore := 0, 0
+	patNoPlay := false
+	add := func(s *Score, points int) {
+		s.Points += points
+		switch {
+		case s.ClubCode == homeClubCode:
+			homeScore += points
+		case s.ClubCode == visitorClubCode:
+			visitorScore += points
+		}
+		s.HomeScore = homeScore
+		s.VisitorScore = visitorScore
+	}
+	for _, p := range f.Play {
+		if p.PlayDeleted != 0 {
+			continue
+		}
+		playStats := stats[p.PlayID]
+
+		// A try that's nullified by a penalty or never attempted is replaced by the next one.
+		if p.PlayType == PlayTypeTry && len(ret) > 0 {
+			if last := ret[len(ret)-1]; last.Type == ScoreTypeTouchdown && (last.PATPlay == nil || last.PATResult == PATResultNone || patNoPlay) {
+				add(last, -last.PATResult.Points())
+				last.PATPlay = p
+				last.PATResult = patResult(p, playStats)
+				add(last, last.PATResult.Points())
+				patNoPlay = ParsePlayDescription(p.PlayDescription).NoPlay
+			}
+		}
+
+		for _, stat := range playStats {
+			var t ScoreType
+			switch kind, _ := statPoints(stat.StatID); kind {
+			case "TD":
+				t = ScoreTypeTouchdown
+			case "FG":
+				t = ScoreTypeFieldGoal
+			case "SAFETY":
+				t = ScoreTypeSafety
+			case "D2PT":
+				t = ScoreTypeDefensiveTwoPoint
+			default:
+				continue
+			}
+			s := &Score{
+				Type:     t,
+				ClubCode: stat.ClubCode,
+				Play:     p,
+			}
+			s.setScorer(playStats)
+			add(s, t.points())
+			ret = append(ret, s)
+			break
+		}
+	}
+	return ret
+}
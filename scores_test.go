@@ -0,0 +1,127 @@
+package gsis
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type scoreSummary struct {
+	Type         ScoreType
+	ClubCode     string
+	PlayID       int
+	PATPlayID    int
+	PATResult    PATResult
+	Points       int
+	HomeScore    int
+	VisitorScore int
+}
+
+func summarizeScores(scores []*Score) []scoreSummary {
+	ret := make([]scoreSummary, len(scores))
+	for i, s := range scores {
+		ret[i] = scoreSummary{
+			Type:         s.Type,
+			ClubCode:     s.ClubCode,
+			PATResult:    s.PATResult,
+			Points:       s.Points,
+			HomeScore:    s.HomeScore,
+			VisitorScore: s.VisitorScore,
+		}
+		if s.Play != nil {
+			ret[i].PlayID = int(s.Play.PlayID)
+		}
+		if s.PATPlay != nil {
+			ret[i].PATPlayID = int(s.PATPlay.PlayID)
+		}
+	}
+	return ret
+}
+
+func TestStatFile_Scores(t *testing.T) {
+	buf, err := ioutil.ReadFile("testdata/signalr-stats.json")
+	require.NoError(t, err)
+
+	var stats StatFile
+	require.NoError(t, json.Unmarshal(buf, &stats))
+
+	scores := stats.Scores()
+	require.Len(t, scores, 11)
+	for _, s := range scores {
+		assert.NotNil(t, s.Event)
+		assert.NotNil(t, s.Play)
+		assert.NotNil(t, s.Scorer)
+	}
+
+	// A passing touchdown.
+	assert.Equal(t, "J.Witten", scores[0].Scorer.PlayerName)
+	require.NotNil(t, scores[0].Passer)
+	assert.Equal(t, "D.Prescott", scores[0].Passer.PlayerName)
+
+	// A rushing touchdown.
+	assert.Equal(t, "E.Elliott", scores[3].Scorer.PlayerName)
+	assert.Nil(t, scores[3].Passer)
+
+	// A field goal.
+	assert.Equal(t, ScoreTypeFieldGoal, scores[5].Type)
+	assert.Equal(t, "K.Forbath", scores[5].Scorer.PlayerName)
+	assert.Nil(t, scores[5].PATPlay)
+
+	// The first two-point attempt was nullified by a penalty and the second one succeeded.
+	assert.Equal(t, scoreSummary{
+		Type:         ScoreTypeTouchdown,
+		ClubCode:     "LA",
+		PlayID:       3831,
+		PATPlayID:    3885,
+		PATResult:    PATResultTwoPointGood,
+		Points:       8,
+		HomeScore:    37,
+		VisitorScore: 15,
+	}, summarizeScores(scores)[8])
+
+	assert.Equal(t, scoreSummary{
+		Type:         ScoreTypeTouchdown,
+		ClubCode:     "LA",
+		PlayID:       4307,
+		PATPlayID:    4332,
+		PATResult:    PATResultTwoPointFailed,
+		Points:       6,
+		HomeScore:    44,
+		VisitorScore: 21,
+	}, summarizeScores(scores)[10])
+
+	t.Run("Synthesized", func(t *testing.T) {
+		expected := summarizeScores(scores)
+		stats.ScoringSummary = nil
+		synthesized := stats.Scores()
+		for _, s := range synthesized {
+			assert.Nil(t, s.Event)
+		}
+		assert.Equal(t, expected, summarizeScores(synthesized))
+	})
+}
+
+func TestStatFile_Scores_Games(t *testing.T) {
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		if len(stats.ScoringSummary) == 0 || len(stats.Play) < 2 {
+			return
+		}
+
+		scores := stats.Scores()
+		for _, s := range scores {
+			assert.NotEmpty(t, s.Type)
+			if s.Play != nil {
+				assert.NotNil(t, s.Scorer, "play %v", s.Play.PlayID)
+			}
+		}
+
+		// The scores found in the plays should match the scoring summary.
+		expected := summarizeScores(scores)
+		withoutSummary := *stats
+		withoutSummary.ScoringSummary = nil
+		assert.Equal(t, expected, summarizeScores(withoutSummary.Scores()))
+	})
+}
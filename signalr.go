@@ -2,11 +2,13 @@ package gsis
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
 	"strconv"
 	"strings"
@@ -26,18 +28,83 @@ type SignalRClient struct {
 
 	Logger logrus.FieldLogger
 
+	// Options for the connections made by the client.
+	ConnectionOptions SignalRConnectionOptions
+
+	// Options for the negotiate request and the websocket handshake.
+	DialOptions SignalRDialOptions
+
 	conn             *SignalRConnection
+	jar              http.CookieJar
+	subscriptions    *SignalRSubscriptions
 	connectError     error
 	connectErrorTime time.Time
 	connMutex        sync.Mutex
 }
 
+type SignalRDialOptions struct {
+	// If true, permessage-deflate compression is negotiated for the websocket. Stat files are
+	// hundreds of kilobytes, so this is usually worthwhile.
+	EnableCompression bool
+
+	// Headers sent with the negotiate request and the websocket handshake.
+	Header http.Header
+
+	// Cookies sent with the negotiate request and the websocket handshake. If nil, the client uses
+	// its own jar so that cookies set during negotiation are sent with the handshake.
+	Jar http.CookieJar
+
+	// The proxy for the negotiate request and the websocket handshake. By default the proxy is
+	// determined by the environment.
+	Proxy func(*http.Request) (*url.URL, error)
+
+	// The TLS configuration for the negotiate request and the websocket handshake.
+	TLSClientConfig *tls.Config
+
+	// The time limit for the negotiate request and for the websocket handshake. By default this is
+	// 45 seconds.
+	HandshakeTimeout time.Duration
+
+	// The maximum size in bytes of a message read from the websocket. By default there is no limit.
+	ReadLimit int64
+}
+
+func (o *SignalRDialOptions) proxy() func(*http.Request) (*url.URL, error) {
+	if o.Proxy != nil {
+		return o.Proxy
+	}
+	return http.ProxyFromEnvironment
+}
+
+func (o *SignalRDialOptions) handshakeTimeout() time.Duration {
+	if o.HandshakeTimeout > 0 {
+		return o.HandshakeTimeout
+	}
+	return 45 * time.Second
+}
+
+// Must be called with connMutex held.
+func (c *SignalRClient) cookieJar() http.CookieJar {
+	if c.DialOptions.Jar != nil {
+		return c.DialOptions.Jar
+	}
+	if c.jar == nil {
+		// cookiejar.New never returns an error
+		c.jar, _ = cookiejar.New(nil)
+	}
+	return c.jar
+}
+
 type SignalRConnection struct {
-	conn   *websocket.Conn
-	logger logrus.FieldLogger
+	conn    *websocket.Conn
+	logger  logrus.FieldLogger
+	metrics SignalRMetrics
 
 	outgoing chan *websocket.PreparedMessage
 
+	// Limits the number of invocations awaiting a response. Nil if there is no limit.
+	pendingInvocations chan struct{}
+
 	readLoopDone     chan struct{}
 	writeLoopDone    chan struct{}
 	beginClosingOnce sync.Once
@@ -47,18 +114,68 @@ type SignalRConnection struct {
 	invocationChannels map[int]chan *SignalRServerMessage
 	invocationsClosed  bool
 	nextInvocationId   int
+
+	hubMessageMutex       sync.Mutex
+	hubMessageSubscribers map[int]*signalRHubMessageSubscriber
+	hubMessagesClosed     bool
+	nextHubMessageId      int
+}
+
+type signalRHubMessageSubscriber struct {
+	hub string
+	ch  chan *SignalRHubMessage
+}
+
+type SignalRConnectionOptions struct {
+	// The number of outgoing messages that can be queued before invocations block. By default this
+	// is 100.
+	OutgoingQueueSize int
+
+	// The maximum number of invocations that can await a response at once. Further invocations
+	// block until one completes. By default there is no limit.
+	MaxPendingInvocations int
+
+	// If given, receives metrics for the connection.
+	Metrics SignalRMetrics
+}
+
+// SignalRMetrics receives metrics from a SignalRConnection. Its methods may be called concurrently.
+type SignalRMetrics interface {
+	// Called with the number of messages in the outgoing queue whenever it changes.
+	ObserveOutgoingQueueDepth(depth int)
+
+	// Called when an invocation completes, successfully or not. The latency includes any time
+	// spent waiting for space in the outgoing queue.
+	ObserveInvocation(hub, method string, latency time.Duration, err error)
 }
 
 func NewSignalRConnection(conn *websocket.Conn, logger logrus.FieldLogger) *SignalRConnection {
+	return NewSignalRConnectionWithOptions(conn, logger, SignalRConnectionOptions{})
+}
+
+func NewSignalRConnectionWithOptions(conn *websocket.Conn, logger logrus.FieldLogger, options SignalRConnectionOptions) *SignalRConnection {
+	queueSize := options.OutgoingQueueSize
+	if queueSize <= 0 {
+		queueSize = 100
+	}
+	if logger == nil {
+		logger = logrus.StandardLogger()
+	}
 	ret := &SignalRConnection{
 		conn:               conn,
 		logger:             logger,
-		outgoing:           make(chan *websocket.PreparedMessage, 100),
+		metrics:            options.Metrics,
+		outgoing:           make(chan *websocket.PreparedMessage, queueSize),
 		readLoopDone:       make(chan struct{}),
 		writeLoopDone:      make(chan struct{}),
 		close:              make(chan struct{}),
 		invocationChannels: make(map[int]chan *SignalRServerMessage),
 		nextInvocationId:   0,
+
+		hubMessageSubscribers: make(map[int]*signalRHubMessageSubscriber),
+	}
+	if options.MaxPendingInvocations > 0 {
+		ret.pendingInvocations = make(chan struct{}, options.MaxPendingInvocations)
 	}
 	go ret.readLoop()
 	go ret.writeLoop()
@@ -88,18 +205,14 @@ func (c *SignalRConnection) readLoop() {
 func (c *SignalRConnection) writeLoop() {
 	defer c.finishClosing()
 	defer close(c.writeLoopDone)
-	defer close(c.outgoing)
 
 	defer c.conn.Close()
 
 	for {
 		var msg *websocket.PreparedMessage
 		select {
-		case outgoing, ok := <-c.outgoing:
-			if !ok {
-				return
-			}
-			msg = outgoing
+		case msg = <-c.outgoing:
+			c.observeOutgoingQueueDepth()
 		case <-c.close:
 			return
 		}
@@ -138,6 +251,53 @@ func (c *SignalRConnection) handleMessage(buf []byte) {
 		default:
 		}
 	}
+
+	if len(msg.M) > 0 {
+		c.hubMessageMutex.Lock()
+		defer c.hubMessageMutex.Unlock()
+		for i := range msg.M {
+			hubMessage := &msg.M[i]
+			for _, subscriber := range c.hubMessageSubscribers {
+				if !strings.EqualFold(subscriber.hub, hubMessage.H) {
+					continue
+				}
+				select {
+				case subscriber.ch <- hubMessage:
+				default:
+					c.logger.Warnf("dropping %v.%v message for slow subscriber", hubMessage.H, hubMessage.M)
+				}
+			}
+		}
+	}
+}
+
+// SubscribeHubMessages returns a channel that receives the messages the server pushes for the given
+// hub. The channel is closed when the connection closes or when the returned function is called.
+// Messages are dropped if the channel's buffer is full, so subscribers should keep up.
+func (c *SignalRConnection) SubscribeHubMessages(hub string, bufferSize int) (<-chan *SignalRHubMessage, func()) {
+	ch := make(chan *SignalRHubMessage, bufferSize)
+
+	c.hubMessageMutex.Lock()
+	defer c.hubMessageMutex.Unlock()
+	if c.hubMessagesClosed {
+		close(ch)
+		return ch, func() {}
+	}
+	id := c.nextHubMessageId
+	c.nextHubMessageId++
+	c.hubMessageSubscribers[id] = &signalRHubMessageSubscriber{
+		hub: hub,
+		ch:  ch,
+	}
+
+	return ch, func() {
+		c.hubMessageMutex.Lock()
+		defer c.hubMessageMutex.Unlock()
+		if _, ok := c.hubMessageSubscribers[id]; ok {
+			delete(c.hubMessageSubscribers, id)
+			close(ch)
+		}
+	}
 }
 
 func (c *SignalRConnection) beginClosing() {
@@ -156,6 +316,14 @@ func (c *SignalRConnection) finishClosing() {
 		close(ch)
 	}
 	c.invocationChannels = nil
+
+	c.hubMessageMutex.Lock()
+	defer c.hubMessageMutex.Unlock()
+	c.hubMessagesClosed = true
+	for id, subscriber := range c.hubMessageSubscribers {
+		close(subscriber.ch)
+		delete(c.hubMessageSubscribers, id)
+	}
 }
 
 func (c *SignalRConnection) Close() error {
@@ -193,11 +361,70 @@ type SignalRServerMessage struct {
 
 	// The payload if this is a response to a client message.
 	R json.RawMessage
+
+	// An error message if this is a response to a client message that failed.
+	E string
+
+	// Hub messages pushed by the server.
+	M []SignalRHubMessage
+}
+
+// A message pushed by the server to invoke a method on the client.
+type SignalRHubMessage struct {
+	// The hub.
+	H string
+
+	// The name of the method.
+	M string
+
+	// The method arguments.
+	A []json.RawMessage
 }
 
 var ErrSignalRConnectionClosed = fmt.Errorf("signalr connection closed")
 
-func (c *SignalRConnection) Invoke(ctx context.Context, hub, method string, args ...interface{}) (json.RawMessage, error) {
+// QueueDepth returns the number of messages waiting to be written to the websocket.
+func (c *SignalRConnection) QueueDepth() int {
+	return len(c.outgoing)
+}
+
+// PendingInvocations returns the number of invocations awaiting a response.
+func (c *SignalRConnection) PendingInvocations() int {
+	c.invocationMutex.Lock()
+	defer c.invocationMutex.Unlock()
+	return len(c.invocationChannels)
+}
+
+func (c *SignalRConnection) observeOutgoingQueueDepth() {
+	if c.metrics != nil {
+		c.metrics.ObserveOutgoingQueueDepth(len(c.outgoing))
+	}
+}
+
+// Invoke invokes a hub method and waits for the response. If the outgoing queue is full or too many
+// invocations are pending, it blocks until there is room, the context is done, or the connection is
+// closed.
+func (c *SignalRConnection) Invoke(ctx context.Context, hub, method string, args ...interface{}) (result json.RawMessage, err error) {
+	if c.metrics != nil {
+		start := time.Now()
+		defer func() {
+			c.metrics.ObserveInvocation(hub, method, time.Since(start), err)
+		}()
+	}
+
+	if c.pendingInvocations != nil {
+		select {
+		case c.pendingInvocations <- struct{}{}:
+			defer func() {
+				<-c.pendingInvocations
+			}()
+		case <-c.close:
+			return nil, ErrSignalRConnectionClosed
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
 	c.invocationMutex.Lock()
 	if c.invocationsClosed {
 		c.invocationMutex.Unlock()
@@ -212,6 +439,7 @@ func (c *SignalRConnection) Invoke(ctx context.Context, hub, method string, args
 		}
 		id = (id + 1) % 0x80000000
 		if id == c.nextInvocationId {
+			c.invocationMutex.Unlock()
 			return nil, fmt.Errorf("no unallocated invocation ids")
 		}
 	}
@@ -243,14 +471,19 @@ func (c *SignalRConnection) Invoke(ctx context.Context, hub, method string, args
 
 	select {
 	case c.outgoing <- p:
-	default:
-		return nil, fmt.Errorf("output buffer full")
+		c.observeOutgoingQueueDepth()
+	case <-c.close:
+		return nil, ErrSignalRConnectionClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
 
 	select {
 	case resp, ok := <-ch:
 		if !ok {
 			return nil, ErrSignalRConnectionClosed
+		} else if resp.E != "" {
+			return nil, fmt.Errorf("hub error: %v", resp.E)
 		}
 		return resp.R, nil
 	case <-ctx.Done():
@@ -278,12 +511,30 @@ func (c *SignalRClient) Connection() (*SignalRConnection, error) {
 		c.connectError = err
 		c.connectErrorTime = now
 	} else {
-		c.conn = NewSignalRConnection(conn, c.Logger)
+		c.conn = NewSignalRConnectionWithOptions(conn, c.Logger, c.ConnectionOptions)
+		c.subscriptions = NewSignalRSubscriptions(c.conn)
 		c.connectError = nil
 	}
 	return c.conn, c.connectError
 }
 
+// Subscriptions returns the subscriptions for the current connection, connecting if necessary.
+// Each connection has its own subscriptions, so if the connection is lost, registrations must be
+// made again on the new one.
+func (c *SignalRClient) Subscriptions() (*SignalRSubscriptions, error) {
+	conn, err := c.Connection()
+	if err != nil {
+		return nil, err
+	}
+
+	c.connMutex.Lock()
+	defer c.connMutex.Unlock()
+	if c.subscriptions == nil || c.subscriptions.Connection() != conn {
+		return nil, ErrSignalRConnectionClosed
+	}
+	return c.subscriptions, nil
+}
+
 func (c *SignalRClient) Close() error {
 	c.connMutex.Lock()
 	defer c.connMutex.Unlock()
@@ -295,8 +546,30 @@ func (c *SignalRClient) Close() error {
 }
 
 func (c *SignalRClient) connect() (*websocket.Conn, error) {
+	options := &c.DialOptions
+	jar := c.cookieJar()
+
+	transport := &http.Transport{
+		Proxy:           options.proxy(),
+		TLSClientConfig: options.TLSClientConfig,
+	}
+	defer transport.CloseIdleConnections()
+	httpClient := &http.Client{
+		Transport: transport,
+		Jar:       jar,
+		Timeout:   options.handshakeTimeout(),
+	}
+
+	dialer := &websocket.Dialer{
+		Proxy:             options.proxy(),
+		TLSClientConfig:   options.TLSClientConfig,
+		HandshakeTimeout:  options.handshakeTimeout(),
+		EnableCompression: options.EnableCompression,
+		Jar:               jar,
+	}
+
 	for attempt := 0; ; attempt++ {
-		resp, err := c.doNegotiateRequest()
+		resp, err := c.doNegotiateRequest(httpClient)
 		if err != nil {
 			if attempt >= 2 {
 				return nil, fmt.Errorf("negotiate error: %w", err)
@@ -331,7 +604,7 @@ func (c *SignalRClient) connect() (*websocket.Conn, error) {
 			connectURL.Scheme = "wss"
 		}
 
-		conn, _, err := websocket.DefaultDialer.Dial(connectURL.String(), nil)
+		conn, _, err := dialer.Dial(connectURL.String(), options.Header.Clone())
 		if err != nil {
 			if attempt >= 2 {
 				return nil, fmt.Errorf("websocket dial error: %w", err)
@@ -339,6 +612,9 @@ func (c *SignalRClient) connect() (*websocket.Conn, error) {
 			time.Sleep(time.Second)
 			continue
 		}
+		if options.ReadLimit > 0 {
+			conn.SetReadLimit(options.ReadLimit)
+		}
 		return conn, nil
 	}
 }
@@ -349,7 +625,7 @@ type SignalRNegotiateResponse struct {
 	TryWebSockets   bool
 }
 
-func (c *SignalRClient) doNegotiateRequest() (*SignalRNegotiateResponse, error) {
+func (c *SignalRClient) doNegotiateRequest(httpClient *http.Client) (*SignalRNegotiateResponse, error) {
 	signalrURL, err := url.Parse(c.URL + "/")
 	if err != nil {
 		return nil, fmt.Errorf("error parsing url: %w", err)
@@ -363,7 +639,15 @@ func (c *SignalRClient) doNegotiateRequest() (*SignalRNegotiateResponse, error)
 		}.Encode(),
 	})
 
-	resp, err := http.Get(negotiateURL.String())
+	req, err := http.NewRequest("GET", negotiateURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	for k, v := range c.DialOptions.Header {
+		req.Header[k] = v
+	}
+
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request error: %w", err)
 	}
@@ -395,8 +679,11 @@ func (c *SignalRClient) GetStatFile(year int, season string, week, gameKey int)
 	return &statFile, nil
 }
 
+// GetStatFileJSON fetches a game's stat file by registering for the game's schedule week and stats.
+// Both registrations are released before returning, so unless other users of the client's
+// subscriptions hold them, the hub is told to unregister the schedule week as well as the game.
 func (c *SignalRClient) GetStatFileJSON(year int, season string, week, gameKey int) (json.RawMessage, error) {
-	conn, err := c.Connection()
+	subscriptions, err := c.Subscriptions()
 	if err != nil {
 		return nil, fmt.Errorf("connection error: %w", err)
 	}
@@ -404,19 +691,24 @@ func (c *SignalRClient) GetStatFileJSON(year int, season string, week, gameKey i
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	if _, err := conn.Invoke(ctx, "schedulehub", "RegisterForSchedule", strconv.Itoa(year), strings.ToUpper(season), week); err != nil {
+	if _, err := subscriptions.RegisterForSchedule(ctx, year, season, week); err != nil {
 		return nil, fmt.Errorf("error registering for schedule: %w", err)
 	}
 
 	defer func() {
-		if _, err := conn.Invoke(ctx, "gamestatshub", "UnregisterForStats", ""); err != nil {
-			c.Logger.Warn(fmt.Errorf("error unregistering for stats: %w", err))
+		if err := subscriptions.UnregisterForSchedule(ctx, year, season, week); err != nil {
+			c.Logger.Warn(fmt.Errorf("error unregistering for schedule: %w", err))
 		}
 	}()
 
-	if statsJSON, err := conn.Invoke(ctx, "gamestatshub", "RegisterForStats", strconv.Itoa(gameKey)); err != nil {
+	statsJSON, err := subscriptions.RegisterForStats(ctx, gameKey)
+	if err != nil {
 		return nil, fmt.Errorf("error registering for stats: %w", err)
-	} else {
-		return statsJSON, nil
 	}
+
+	if err := subscriptions.UnregisterForStats(ctx, gameKey); err != nil {
+		c.Logger.Warn(fmt.Errorf("error unregistering for stats: %w", err))
+	}
+
+	return statsJSON, nil
 }
@@ -0,0 +1,229 @@
+package gsis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SignalRSubscriptions tracks the games and schedule weeks registered on a single SignalR
+// connection. Registrations are reference-counted so that many goroutines can share the
+// connection: the hub is only told to unregister once the last user of a registration releases
+// it.
+type SignalRSubscriptions struct {
+	conn *SignalRConnection
+
+	mutex         sync.Mutex
+	subscriptions map[string]*signalRSubscription
+}
+
+type signalRSubscription struct {
+	// Serializes register and unregister invocations for the subscription.
+	mutex      sync.Mutex
+	registered bool
+
+	// Protected by the SignalRSubscriptions mutex.
+	refs int
+}
+
+// A schedule week that can be registered for via the schedulehub.
+type SignalRSchedule struct {
+	Season     int
+	SeasonType string
+	Week       int
+}
+
+func (s SignalRSchedule) key() string {
+	return fmt.Sprintf("schedule/%d/%v/%d", s.Season, strings.ToUpper(s.SeasonType), s.Week)
+}
+
+func signalRStatsKey(gameKey int) string {
+	return "stats/" + strconv.Itoa(gameKey)
+}
+
+func NewSignalRSubscriptions(conn *SignalRConnection) *SignalRSubscriptions {
+	return &SignalRSubscriptions{
+		conn:          conn,
+		subscriptions: make(map[string]*signalRSubscription),
+	}
+}
+
+// Connection returns the connection that the subscriptions are registered on.
+func (s *SignalRSubscriptions) Connection() *SignalRConnection {
+	return s.conn
+}
+
+// RegisterForSchedule registers for a schedule week and returns the hub's response. Every call must
+// be paired with a call to UnregisterForSchedule.
+func (s *SignalRSubscriptions) RegisterForSchedule(ctx context.Context, season int, seasonType string, week int) (json.RawMessage, error) {
+	return s.acquire(SignalRSchedule{season, seasonType, week}.key(), func() (json.RawMessage, error) {
+		return s.conn.Invoke(ctx, "schedulehub", "RegisterForSchedule", strconv.Itoa(season), strings.ToUpper(seasonType), week)
+	}, s.unregisterForSchedule(ctx, season, seasonType, week))
+}
+
+// UnregisterForSchedule releases a registration made by RegisterForSchedule. The hub is only
+// invoked once there are no remaining registrations for the week.
+func (s *SignalRSubscriptions) UnregisterForSchedule(ctx context.Context, season int, seasonType string, week int) error {
+	return s.release(SignalRSchedule{season, seasonType, week}.key(), s.unregisterForSchedule(ctx, season, seasonType, week))
+}
+
+func (s *SignalRSubscriptions) unregisterForSchedule(ctx context.Context, season int, seasonType string, week int) func() error {
+	return func() error {
+		_, err := s.conn.Invoke(ctx, "schedulehub", "UnregisterForSchedule", strconv.Itoa(season), strings.ToUpper(seasonType), week)
+		return err
+	}
+}
+
+// RegisterForStats registers for a game's stats and returns the hub's response, which is the
+// game's current stat file. Every call must be paired with a call to UnregisterForStats.
+func (s *SignalRSubscriptions) RegisterForStats(ctx context.Context, gameKey int) (json.RawMessage, error) {
+	return s.acquire(signalRStatsKey(gameKey), func() (json.RawMessage, error) {
+		return s.conn.Invoke(ctx, "gamestatshub", "RegisterForStats", strconv.Itoa(gameKey))
+	}, s.unregisterForStats(ctx, gameKey))
+}
+
+// UnregisterForStats releases a registration made by RegisterForStats. The hub is only invoked
+// once there are no remaining registrations for the game.
+func (s *SignalRSubscriptions) UnregisterForStats(ctx context.Context, gameKey int) error {
+	return s.release(signalRStatsKey(gameKey), s.unregisterForStats(ctx, gameKey))
+}
+
+func (s *SignalRSubscriptions) unregisterForStats(ctx context.Context, gameKey int) func() error {
+	return func() error {
+		_, err := s.conn.Invoke(ctx, "gamestatshub", "UnregisterForStats", strconv.Itoa(gameKey))
+		return err
+	}
+}
+
+// Games returns the keys of the games that are currently registered, in ascending order.
+func (s *SignalRSubscriptions) Games() []int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var ret []int
+	for key, sub := range s.subscriptions {
+		if sub.refs > 0 && strings.HasPrefix(key, "stats/") {
+			if gameKey, err := strconv.Atoi(strings.TrimPrefix(key, "stats/")); err == nil {
+				ret = append(ret, gameKey)
+			}
+		}
+	}
+	sort.Ints(ret)
+	return ret
+}
+
+// Schedules returns the schedule weeks that are currently registered.
+func (s *SignalRSubscriptions) Schedules() []SignalRSchedule {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var ret []SignalRSchedule
+	for key, sub := range s.subscriptions {
+		if sub.refs == 0 || !strings.HasPrefix(key, "schedule/") {
+			continue
+		}
+		parts := strings.Split(strings.TrimPrefix(key, "schedule/"), "/")
+		if len(parts) != 3 {
+			continue
+		}
+		schedule := SignalRSchedule{
+			SeasonType: parts[1],
+		}
+		schedule.Season, _ = strconv.Atoi(parts[0])
+		schedule.Week, _ = strconv.Atoi(parts[2])
+		ret = append(ret, schedule)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].key() < ret[j].key()
+	})
+	return ret
+}
+
+// RefCount returns the number of outstanding registrations for a game.
+func (s *SignalRSubscriptions) RefCount(gameKey int) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if sub, ok := s.subscriptions[signalRStatsKey(gameKey)]; ok {
+		return sub.refs
+	}
+	return 0
+}
+
+// The unregister function is invoked if registration fails after every other user has released
+// the subscription, since none of them will have unregistered it.
+func (s *SignalRSubscriptions) acquire(key string, register func() (json.RawMessage, error), unregister func() error) (json.RawMessage, error) {
+	s.mutex.Lock()
+	sub, ok := s.subscriptions[key]
+	if !ok {
+		sub = &signalRSubscription{}
+		s.subscriptions[key] = sub
+	}
+	sub.refs++
+	s.mutex.Unlock()
+
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+
+	// The hub's response carries the current state, so we register even if another user already
+	// has. Registering for something that is already registered is harmless.
+	resp, err := register()
+	if err != nil {
+		s.mutex.Lock()
+		sub.refs--
+		last := sub.refs == 0
+		s.mutex.Unlock()
+
+		if last && sub.registered {
+			if err := unregister(); err != nil {
+				s.conn.logger.Warn(fmt.Errorf("error unregistering for %v: %w", key, err))
+			}
+			sub.registered = false
+		}
+
+		s.mutex.Lock()
+		s.removeIfUnused(key, sub)
+		s.mutex.Unlock()
+		return nil, err
+	}
+	sub.registered = true
+	return resp, nil
+}
+
+func (s *SignalRSubscriptions) release(key string, unregister func() error) error {
+	s.mutex.Lock()
+	sub, ok := s.subscriptions[key]
+	if !ok || sub.refs == 0 {
+		s.mutex.Unlock()
+		return fmt.Errorf("not registered: %v", key)
+	}
+	sub.refs--
+	s.mutex.Unlock()
+
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+
+	// Another user may have registered while we were waiting for the lock.
+	s.mutex.Lock()
+	last := sub.refs == 0
+	s.mutex.Unlock()
+	if !last || !sub.registered {
+		return nil
+	}
+
+	err := unregister()
+	sub.registered = false
+
+	s.mutex.Lock()
+	s.removeIfUnused(key, sub)
+	s.mutex.Unlock()
+	return err
+}
+
+// Must be called with both the subscription's mutex and the mutex held.
+func (s *SignalRSubscriptions) removeIfUnused(key string, sub *signalRSubscription) {
+	if sub.refs == 0 && !sub.registered && s.subscriptions[key] == sub {
+		delete(s.subscriptions, key)
+	}
+}
@@ -0,0 +1,231 @@
+package gsis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sportsball-ai/gsis/gsistest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignalRSubscriptions(t *testing.T) {
+	var invocationsMutex sync.Mutex
+	var invocations []SignalRClientMessage
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/negotiate" {
+			w.Write([]byte(`{"Url":"/","ConnectionToken":"token","TryWebSockets":true}`))
+		} else if r.URL.Path == "/connect" {
+			conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			for {
+				_, p, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				var msg SignalRClientMessage
+				require.NoError(t, json.Unmarshal(p, &msg))
+				invocationsMutex.Lock()
+				invocations = append(invocations, msg)
+				invocationsMutex.Unlock()
+				resp, err := json.Marshal(map[string]interface{}{
+					"I": strconv.Itoa(msg.I),
+					"R": map[string]interface{}{"method": msg.M, "args": msg.A},
+				})
+				require.NoError(t, err)
+				require.NoError(t, conn.WriteMessage(websocket.TextMessage, resp))
+			}
+		} else {
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	c := &SignalRClient{
+		URL:            ts.URL,
+		ConnectionData: `[{"name":"gamestatshub"},{"name":"schedulehub"}]`,
+	}
+	defer c.Close()
+
+	subscriptions, err := c.Subscriptions()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for _, gameKey := range []int{58155, 58156, 58155} {
+		wg.Add(1)
+		go func(gameKey int) {
+			defer wg.Done()
+			_, err := subscriptions.RegisterForStats(ctx, gameKey)
+			assert.NoError(t, err)
+		}(gameKey)
+	}
+	wg.Wait()
+
+	assert.Equal(t, []int{58155, 58156}, subscriptions.Games())
+	assert.Equal(t, 2, subscriptions.RefCount(58155))
+
+	_, err = subscriptions.RegisterForSchedule(ctx, 2019, "reg", 17)
+	require.NoError(t, err)
+	assert.Equal(t, []SignalRSchedule{{Season: 2019, SeasonType: "REG", Week: 17}}, subscriptions.Schedules())
+
+	countUnregistrations := func() map[string]int {
+		invocationsMutex.Lock()
+		defer invocationsMutex.Unlock()
+		ret := map[string]int{}
+		for _, msg := range invocations {
+			if msg.M == "UnregisterForStats" || msg.M == "UnregisterForSchedule" {
+				ret[msg.M+" "+msg.A[0].(string)]++
+			}
+		}
+		return ret
+	}
+
+	require.NoError(t, subscriptions.UnregisterForStats(ctx, 58155))
+	assert.Empty(t, countUnregistrations())
+	assert.Equal(t, []int{58155, 58156}, subscriptions.Games())
+
+	require.NoError(t, subscriptions.UnregisterForStats(ctx, 58155))
+	assert.Equal(t, map[string]int{"UnregisterForStats 58155": 1}, countUnregistrations())
+	assert.Equal(t, []int{58156}, subscriptions.Games())
+
+	require.NoError(t, subscriptions.UnregisterForStats(ctx, 58156))
+	require.NoError(t, subscriptions.UnregisterForSchedule(ctx, 2019, "REG", 17))
+	assert.Equal(t, map[string]int{
+		"UnregisterForStats 58155":   1,
+		"UnregisterForStats 58156":   1,
+		"UnregisterForSchedule 2019": 1,
+	}, countUnregistrations())
+	assert.Empty(t, subscriptions.Games())
+	assert.Empty(t, subscriptions.Schedules())
+
+	assert.Error(t, subscriptions.UnregisterForStats(ctx, 58156))
+}
+
+func TestSignalRSubscriptions_Reconnect(t *testing.T) {
+	hub := gsistest.NewSignalRHub()
+	defer hub.Close()
+	hub.Handle("gamestatshub", "RegisterForStats", func(args []json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{"GameKey": args[0]}, nil
+	})
+	hub.Handle("gamestatshub", "UnregisterForStats", func(args []json.RawMessage) (interface{}, error) {
+		return nil, nil
+	})
+
+	c := &SignalRClient{
+		URL:            hub.URL,
+		ConnectionData: `[{"name":"gamestatshub"},{"name":"schedulehub"}]`,
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+
+	subscriptions, err := c.Subscriptions()
+	require.NoError(t, err)
+	resp, err := subscriptions.RegisterForStats(ctx, 58155)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"GameKey":"58155"}`, string(resp))
+	assert.Equal(t, []int{58155}, subscriptions.Games())
+
+	hub.Disconnect()
+	require.Eventually(t, subscriptions.Connection().IsClosed, 5*time.Second, 10*time.Millisecond)
+
+	// The registrations were lost with the connection.
+	assert.Equal(t, ErrSignalRConnectionClosed, subscriptions.UnregisterForStats(ctx, 58155))
+
+	reconnected, err := c.Subscriptions()
+	require.NoError(t, err)
+	assert.NotSame(t, subscriptions, reconnected)
+	assert.Empty(t, reconnected.Games())
+	_, err = reconnected.RegisterForStats(ctx, 58155)
+	require.NoError(t, err)
+	require.NoError(t, reconnected.UnregisterForStats(ctx, 58155))
+
+	invocations := hub.Invocations()
+	require.Len(t, invocations, 3)
+	assert.NotEqual(t, invocations[0].ConnectionToken, invocations[1].ConnectionToken)
+	assert.Equal(t, "UnregisterForStats", invocations[2].Method)
+	assert.Equal(t, 2, hub.Negotiations())
+}
+
+func TestSignalRSubscriptions_FailedRegistration(t *testing.T) {
+	hub := gsistest.NewSignalRHub()
+	defer hub.Close()
+	failRegistration := make(chan struct{})
+	var registrations int32
+	hub.Handle("gamestatshub", "RegisterForStats", func(args []json.RawMessage) (interface{}, error) {
+		switch atomic.AddInt32(&registrations, 1) {
+		case 1:
+			return nil, nil
+		case 2:
+			<-failRegistration
+		}
+		return nil, fmt.Errorf("registration failed")
+	})
+	hub.Handle("gamestatshub", "UnregisterForStats", func(args []json.RawMessage) (interface{}, error) {
+		return nil, nil
+	})
+
+	c := &SignalRClient{
+		URL:            hub.URL,
+		ConnectionData: `[{"name":"gamestatshub"},{"name":"schedulehub"}]`,
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+
+	subscriptions, err := c.Subscriptions()
+	require.NoError(t, err)
+	_, err = subscriptions.RegisterForStats(ctx, 58155)
+	require.NoError(t, err)
+
+	waitForRefCount := func(n int) {
+		require.Eventually(t, func() bool {
+			return subscriptions.RefCount(58155) == n
+		}, 5*time.Second, 10*time.Millisecond)
+		// Give the goroutine time to start waiting for the subscription's lock.
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	registerErrs := make(chan error, 2)
+	register := func() {
+		_, err := subscriptions.RegisterForStats(ctx, 58155)
+		registerErrs <- err
+	}
+
+	// A second registration blocks in the hub, and the first user releases behind it. Then a third
+	// registration queues up, so when the first user gets the lock, it isn't the last user and
+	// leaves the hub registered.
+	go register()
+	hub.WaitForInvocations("gamestatshub", "RegisterForStats", 2, 5*time.Second)
+	unregisterErr := make(chan error, 1)
+	go func() {
+		unregisterErr <- subscriptions.UnregisterForStats(ctx, 58155)
+	}()
+	waitForRefCount(1)
+	go register()
+	waitForRefCount(2)
+
+	// Both registrations fail, so the last of them has to unregister.
+	close(failRegistration)
+	assert.Error(t, <-registerErrs)
+	assert.Error(t, <-registerErrs)
+	assert.NoError(t, <-unregisterErr)
+
+	assert.Len(t, hub.InvocationsOf("gamestatshub", "UnregisterForStats"), 1)
+	assert.Empty(t, subscriptions.Games())
+	assert.Equal(t, 0, subscriptions.RefCount(58155))
+}
@@ -2,9 +2,18 @@ package gsis
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
@@ -75,3 +84,302 @@ func TestSignalRClient_UnexpectedClosure(t *testing.T) {
 	_, err = conn.Invoke(context.Background(), "schedulehub", "RegisterForSchedule", "2019", "REG", 3)
 	assert.Error(t, ErrSignalRConnectionClosed)
 }
+
+type testSignalRMetrics struct {
+	mutex       sync.Mutex
+	depths      []int
+	invocations []string
+}
+
+func (m *testSignalRMetrics) ObserveOutgoingQueueDepth(depth int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.depths = append(m.depths, depth)
+}
+
+func (m *testSignalRMetrics) ObserveInvocation(hub, method string, latency time.Duration, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.invocations = append(m.invocations, fmt.Sprintf("%v.%v %v", hub, method, err))
+}
+
+func TestSignalRClient_MaxPendingInvocations(t *testing.T) {
+	respond := make(chan struct{})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/negotiate" {
+			w.Write([]byte(`{"Url":"/","ConnectionToken":"token","TryWebSockets":true}`))
+		} else if r.URL.Path == "/connect" {
+			conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			for {
+				_, p, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				var msg SignalRClientMessage
+				require.NoError(t, json.Unmarshal(p, &msg))
+				<-respond
+				require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"R": %q, "I": "%d"}`, msg.M, msg.I))))
+			}
+		} else {
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	metrics := &testSignalRMetrics{}
+	c := &SignalRClient{
+		URL:            ts.URL,
+		ConnectionData: `[{"name":"gamestatshub"},{"name":"schedulehub"}]`,
+		ConnectionOptions: SignalRConnectionOptions{
+			OutgoingQueueSize:     1,
+			MaxPendingInvocations: 1,
+			Metrics:               metrics,
+		},
+	}
+	defer c.Close()
+
+	conn, err := c.Connection()
+	require.NoError(t, err)
+
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		resp, err := conn.Invoke(context.Background(), "gamestatshub", "First")
+		assert.NoError(t, err)
+		assert.JSONEq(t, `"First"`, string(resp))
+	}()
+
+	require.Eventually(t, func() bool {
+		return conn.PendingInvocations() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The second invocation must wait for the first rather than failing outright.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = conn.Invoke(ctx, "gamestatshub", "Second")
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	close(respond)
+	<-firstDone
+
+	resp, err := conn.Invoke(context.Background(), "gamestatshub", "Third")
+	require.NoError(t, err)
+	assert.JSONEq(t, `"Third"`, string(resp))
+	assert.Equal(t, 0, conn.PendingInvocations())
+	assert.Equal(t, 0, conn.QueueDepth())
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	assert.Equal(t, []string{
+		"gamestatshub.Second context deadline exceeded",
+		"gamestatshub.First <nil>",
+		"gamestatshub.Third <nil>",
+	}, metrics.invocations)
+	assert.NotEmpty(t, metrics.depths)
+	for _, depth := range metrics.depths {
+		assert.LessOrEqual(t, depth, 1)
+	}
+}
+
+// A connection whose writes block while it's stalled.
+type testStalledConn struct {
+	net.Conn
+	stalled int32
+	writing chan struct{}
+	release chan struct{}
+}
+
+func (c *testStalledConn) Write(p []byte) (int, error) {
+	if atomic.LoadInt32(&c.stalled) != 0 {
+		select {
+		case c.writing <- struct{}{}:
+		default:
+		}
+		<-c.release
+	}
+	return c.Conn.Write(p)
+}
+
+func TestSignalRConnection_FullOutgoingQueue(t *testing.T) {
+	var receivedMutex sync.Mutex
+	var received []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		for {
+			_, p, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg SignalRClientMessage
+			require.NoError(t, json.Unmarshal(p, &msg))
+			receivedMutex.Lock()
+			received = append(received, msg.M)
+			receivedMutex.Unlock()
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"R": %q, "I": "%d"}`, msg.M, msg.I))))
+		}
+	}))
+	defer ts.Close()
+
+	stalledConn := &testStalledConn{
+		writing: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+	dialer := &websocket.Dialer{
+		NetDial: func(network, addr string) (net.Conn, error) {
+			conn, err := net.Dial(network, addr)
+			stalledConn.Conn = conn
+			return stalledConn, err
+		},
+	}
+	wsConn, _, err := dialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	require.NoError(t, err)
+
+	conn := NewSignalRConnectionWithOptions(wsConn, nil, SignalRConnectionOptions{
+		OutgoingQueueSize: 1,
+	})
+	defer conn.Close()
+
+	// Stall the writer on the first invocation, then fill the queue with the second.
+	atomic.StoreInt32(&stalledConn.stalled, 1)
+	var wg sync.WaitGroup
+	for _, method := range []string{"First", "Second"} {
+		wg.Add(1)
+		go func(method string) {
+			defer wg.Done()
+			resp, err := conn.Invoke(context.Background(), "gamestatshub", method)
+			assert.NoError(t, err)
+			assert.JSONEq(t, fmt.Sprintf("%q", method), string(resp))
+		}(method)
+		if method == "First" {
+			<-stalledConn.writing
+		}
+	}
+	require.Eventually(t, func() bool {
+		return conn.QueueDepth() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Invocations block until there's space in the queue or the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = conn.Invoke(ctx, "gamestatshub", "Canceled")
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	thirdDone := make(chan struct{})
+	go func() {
+		defer close(thirdDone)
+		resp, err := conn.Invoke(context.Background(), "gamestatshub", "Third")
+		assert.NoError(t, err)
+		assert.JSONEq(t, `"Third"`, string(resp))
+	}()
+	select {
+	case <-thirdDone:
+		t.Fatal("invocation didn't block on the full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	atomic.StoreInt32(&stalledConn.stalled, 0)
+	close(stalledConn.release)
+	<-thirdDone
+	wg.Wait()
+
+	receivedMutex.Lock()
+	defer receivedMutex.Unlock()
+	assert.Equal(t, []string{"First", "Second", "Third"}, received)
+}
+
+func TestSignalRConnection_InvokeAfterClose(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/negotiate" {
+			w.Write([]byte(`{"Url":"/","ConnectionToken":"token","TryWebSockets":true}`))
+		} else if r.URL.Path == "/connect" {
+			conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+			require.NoError(t, err)
+			conn.ReadMessage()
+			conn.Close()
+		} else {
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	c := &SignalRClient{
+		URL:            ts.URL,
+		ConnectionData: `[{"name":"gamestatshub"},{"name":"schedulehub"}]`,
+	}
+
+	conn, err := c.Connection()
+	require.NoError(t, err)
+	require.NoError(t, c.Close())
+
+	_, err = conn.Invoke(context.Background(), "schedulehub", "RegisterForSchedule", "2019", "REG", 3)
+	assert.Equal(t, ErrSignalRConnectionClosed, err)
+}
+
+func TestSignalRClient_DialOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "bar", r.Header.Get("X-Foo"))
+
+		if r.URL.Path == "/negotiate" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "negotiated"})
+			w.Write([]byte(`{"Url":"/","ConnectionToken":"token","TryWebSockets":true}`))
+		} else if r.URL.Path == "/connect" {
+			cookie, err := r.Cookie("session")
+			require.NoError(t, err)
+			assert.Equal(t, "negotiated", cookie.Value)
+			assert.Contains(t, r.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+
+			conn, err := (&websocket.Upgrader{EnableCompression: true}).Upgrade(w, r, nil)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			for {
+				_, p, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				var msg SignalRClientMessage
+				require.NoError(t, json.Unmarshal(p, &msg))
+				padding := strings.Repeat("x", 100)
+				if msg.M == "Big" {
+					// the read limit applies to compressed frames, so this needs to be incompressible
+					buf := make([]byte, 2000)
+					rand.Read(buf)
+					padding = base64.StdEncoding.EncodeToString(buf)
+				}
+				require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"R": %q, "I": "%d"}`, padding, msg.I))))
+			}
+		} else {
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	c := &SignalRClient{
+		URL:            ts.URL,
+		ConnectionData: `[{"name":"gamestatshub"},{"name":"schedulehub"}]`,
+		DialOptions: SignalRDialOptions{
+			EnableCompression: true,
+			Header:            http.Header{"X-Foo": []string{"bar"}},
+			ReadLimit:         1000,
+		},
+	}
+	defer c.Close()
+
+	conn, err := c.Connection()
+	require.NoError(t, err)
+
+	_, err = conn.Invoke(context.Background(), "gamestatshub", "Small")
+	require.NoError(t, err)
+
+	// Messages larger than the read limit close the connection.
+	_, err = conn.Invoke(context.Background(), "gamestatshub", "Big")
+	assert.Equal(t, ErrSignalRConnectionClosed, err)
+}
@@ -0,0 +1,213 @@
+package gsis
+
+import (
+	"strings"
+	"time"
+)
+
+// The state of a game at a point in time.
+type GameSituation struct {
+	// 1 through 4, then 5 and up for overtime periods.
+	Quarter int
+
+	// Time remaining in the quarter.
+	Clock time.Duration
+
+	HomeScore    int
+	VisitorScore int
+
+	HomeTimeouts    int
+	VisitorTimeouts int
+
+	PossessionTeam string
+
+	// Zero for plays without a down, such as kickoffs and tries.
+	Down      int
+	YardsToGo int
+
+	// The line of scrimmage as the distance from the home team's goal line, or zero if unknown.
+	AbsoluteYardLine int
+
+	// The distance from the line of scrimmage to the possession team's end zone, or zero if
+	// unknown.
+	YardsToEndZone int
+
+	GoalToGo bool
+	RedZone  bool
+}
+
+type PlaySituation struct {
+	Play *StatFilePlay
+
+	// The situation at the snap.
+	Before GameSituation
+
+	// The situation after the play. The possession, down, distance, and field position are those
+	// of the next play, and are unknown after the last play of the game.
+	After GameSituation
+}
+
+// Returns the number of timeouts each team has at the start of a quarter, or zero if they don't
+// get new timeouts.
+func timeoutsForQuarter(quarter int, postseason bool) int {
+	switch {
+	case quarter == 1, quarter == 3:
+		return 3
+	case quarter == 5:
+		if postseason {
+			return 3
+		}
+		return 2
+	case quarter > 5 && postseason && quarter%2 == 1:
+		// Each pair of postseason overtime periods is treated as a half.
+		return 3
+	}
+	return 0
+}
+
+// Situations returns the game situation before and after each actual play, excluding timeouts and
+// two-minute warnings. Deleted plays are ignored. Scores are tracked using the play stats and
+// corrected using the scoring summary when it's available.
+func (f *StatFile) Situations() []*PlaySituation {
+	var homeClubCode, visitorClubCode string
+	postseason := false
+	if f.CumeStatHeader != nil {
+		homeClubCode = f.CumeStatHeader.HomeClubCode
+		visitorClubCode = f.CumeStatHeader.VisitorClubCode
+		postseason = isPostseason(f.CumeStatHeader.SeasonType)
+	}
+	isHome := func(clubCode string) bool {
+		clubCode = strings.Trim(clubCode, `"`)
+		return clubCode != "" && (clubCode == homeClubCode || CommonTeamAbbreviation(clubCode) == CommonTeamAbbreviation(homeClubCode))
+	}
+	isVisitor := func(clubCode string) bool {
+		clubCode = strings.Trim(clubCode, `"`)
+		return clubCode != "" && (clubCode == visitorClubCode || CommonTeamAbbreviation(clubCode) == CommonTeamAbbreviation(visitorClubCode))
+	}
+
+	stats := map[StringInt][]StatFilePlayStat{}
+	for _, stat := range f.PlayStat {
+		stats[stat.PlayID] = append(stats[stat.PlayID], stat)
+	}
+
+	// The scoring summary's score is the score after the try, or after the scoring play if there
+	// was no try.
+	scores := map[StringInt]*StatFileScoringSummaryEvent{}
+	for _, event := range f.ScoringSummary {
+		if event.PATPlayID != 0 {
+			scores[event.PATPlayID] = event
+		} else {
+			scores[event.ScoringPlayID] = event
+		}
+	}
+
+	// The timeouts remaining after each play with a game event.
+	timeouts := map[*StatFilePlay]*GameEvent{}
+	for _, e := range f.GameEvents() {
+		timeouts[e.Play] = e
+	}
+
+	var state GameSituation
+	var ret []*PlaySituation
+	quarter := 0
+
+	for _, p := range f.Play {
+		if p.PlayDeleted != 0 {
+			continue
+		}
+
+		for quarter < int(p.Quarter) {
+			quarter++
+			if n := timeoutsForQuarter(quarter, postseason); n > 0 {
+				state.HomeTimeouts = n
+				state.VisitorTimeouts = n
+			}
+		}
+
+		if p.PlayType == PlayTypeTimeout {
+			if e, ok := timeouts[p]; ok {
+				state.HomeTimeouts = e.HomeTimeouts
+				state.VisitorTimeouts = e.VisitorTimeouts
+			}
+			continue
+		}
+
+		if !p.IsActualPlay() {
+			continue
+		}
+
+		before := state
+		before.Quarter = int(p.Quarter)
+		before.Clock = p.ClockTime.Duration()
+		before.PossessionTeam = strings.Trim(p.PossessionTeam, `"`)
+		before.Down = int(p.Down)
+		before.YardsToGo = int(p.YardsToGo)
+		before.AbsoluteYardLine = 0
+		before.YardsToEndZone = 0
+		if distance, ok := p.YardLine.Distance(before.PossessionTeam); ok {
+			if isHome(before.PossessionTeam) {
+				before.AbsoluteYardLine = distance
+				before.YardsToEndZone = 100 - distance
+			} else if isVisitor(before.PossessionTeam) {
+				before.AbsoluteYardLine = 100 - distance
+				before.YardsToEndZone = 100 - distance
+			}
+		}
+		before.GoalToGo = before.Down > 0 && before.YardsToEndZone > 0 && before.YardsToGo >= before.YardsToEndZone
+		before.RedZone = before.YardsToEndZone > 0 && before.YardsToEndZone <= 20
+
+		scored := map[string]bool{}
+		for _, stat := range stats[p.PlayID] {
+			kind, points := statPoints(stat.StatID)
+			if points == 0 || scored[stat.ClubCode+"/"+kind] {
+				continue
+			}
+			scored[stat.ClubCode+"/"+kind] = true
+			if isHome(stat.ClubCode) {
+				state.HomeScore += points
+			} else if isVisitor(stat.ClubCode) {
+				state.VisitorScore += points
+			}
+		}
+		if event, ok := scores[p.PlayID]; ok {
+			state.HomeScore = int(event.HomeScore)
+			state.VisitorScore = int(event.VisitorScore)
+		}
+		if e, ok := timeouts[p]; ok {
+			// A timeout was charged for a failed challenge of the play.
+			state.HomeTimeouts = e.HomeTimeouts
+			state.VisitorTimeouts = e.VisitorTimeouts
+		}
+
+		ret = append(ret, &PlaySituation{
+			Play:   p,
+			Before: before,
+			After: GameSituation{
+				Quarter:         before.Quarter,
+				Clock:           p.EndClockTime.Duration(),
+				HomeScore:       state.HomeScore,
+				VisitorScore:    state.VisitorScore,
+				HomeTimeouts:    state.HomeTimeouts,
+				VisitorTimeouts: state.VisitorTimeouts,
+			},
+		})
+	}
+
+	for i, s := range ret {
+		if i+1 >= len(ret) {
+			break
+		}
+		next := ret[i+1].Before
+		s.After.PossessionTeam = next.PossessionTeam
+		s.After.Down = next.Down
+		s.After.YardsToGo = next.YardsToGo
+		s.After.AbsoluteYardLine = next.AbsoluteYardLine
+		s.After.YardsToEndZone = next.YardsToEndZone
+		s.After.GoalToGo = next.GoalToGo
+		s.After.RedZone = next.RedZone
+		if s.Play.EndClockTime.IsNil() && next.Quarter == s.After.Quarter {
+			s.After.Clock = next.Clock
+		}
+	}
+	return ret
+}
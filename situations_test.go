@@ -0,0 +1,131 @@
+package gsis
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatFile_Situations(t *testing.T) {
+	f, err := os.Open("testdata/signalr-stats.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	var stats StatFile
+	require.NoError(t, json.NewDecoder(f).Decode(&stats))
+
+	situations := stats.Situations()
+	require.NotEmpty(t, situations)
+
+	byPlayID := map[int]*PlaySituation{}
+	for _, s := range situations {
+		assert.NotEqual(t, PlayTypeTimeout, int(s.Play.PlayType))
+		byPlayID[int(s.Play.PlayID)] = s
+	}
+
+	first := situations[0]
+	assert.Equal(t, GameSituation{
+		Quarter:          1,
+		Clock:            15 * time.Minute,
+		HomeTimeouts:     3,
+		VisitorTimeouts:  3,
+		PossessionTeam:   "DAL",
+		AbsoluteYardLine: 35,
+		YardsToEndZone:   65,
+	}, first.Before)
+
+	// A touchdown on third and goal, just after the two-minute warning and a Rams timeout.
+	touchdown := byPlayID[1850]
+	require.NotNil(t, touchdown)
+	assert.Equal(t, GameSituation{
+		Quarter:          2,
+		Clock:            2 * time.Minute,
+		HomeScore:        14,
+		VisitorScore:     7,
+		HomeTimeouts:     3,
+		VisitorTimeouts:  2,
+		PossessionTeam:   "DAL",
+		Down:             3,
+		YardsToGo:        1,
+		AbsoluteYardLine: 99,
+		YardsToEndZone:   1,
+		GoalToGo:         true,
+		RedZone:          true,
+	}, touchdown.Before)
+	assert.Equal(t, 20, touchdown.After.HomeScore)
+	assert.Equal(t, 7, touchdown.After.VisitorScore)
+	assert.Equal(t, time.Minute+57*time.Second, touchdown.After.Clock)
+	assert.Equal(t, 0, touchdown.After.Down)
+	assert.Equal(t, 85, touchdown.After.AbsoluteYardLine)
+
+	extraPoint := byPlayID[1872]
+	require.NotNil(t, extraPoint)
+	assert.Equal(t, touchdown.After.HomeScore, extraPoint.Before.HomeScore)
+	assert.Equal(t, 21, extraPoint.After.HomeScore)
+
+	// Timeouts are restored at halftime.
+	for _, s := range situations {
+		if s.Before.Quarter == 3 {
+			assert.Equal(t, 3, s.Before.HomeTimeouts)
+			assert.Equal(t, 3, s.Before.VisitorTimeouts)
+			break
+		}
+	}
+
+	twoPoint := byPlayID[3885]
+	require.NotNil(t, twoPoint)
+	assert.Equal(t, twoPoint.Before.VisitorScore+2, twoPoint.After.VisitorScore)
+
+	last := situations[len(situations)-1]
+	assert.Equal(t, int(stats.HomeTeamStats.TotalScore), last.After.HomeScore)
+	assert.Equal(t, int(stats.VisitorTeamStats.TotalScore), last.After.VisitorScore)
+	assert.Equal(t, 0, last.After.VisitorTimeouts)
+	assert.Empty(t, last.After.PossessionTeam)
+}
+
+// Without a header, plays can't be attributed to either team.
+func TestStatFile_Situations_NoHeader(t *testing.T) {
+	stats := StatFile{
+		Play: []*StatFilePlay{
+			{PlayID: 1, Quarter: 1, PlayType: PlayTypePlayFromScrimmage, Down: 1, YardsToGo: 10, YardLine: *NewYardLine("", 50)},
+		},
+		PlayStat: []StatFilePlayStat{
+			{PlayID: 1, StatID: StatIDFieldGoalYards},
+		},
+	}
+	situations := stats.Situations()
+	require.Len(t, situations, 1)
+	assert.Equal(t, 0, situations[0].Before.AbsoluteYardLine)
+	assert.Equal(t, 0, situations[0].Before.YardsToEndZone)
+	assert.Equal(t, 0, situations[0].After.HomeScore)
+	assert.Equal(t, 0, situations[0].After.VisitorScore)
+}
+
+func TestStatFile_Situations_Games(t *testing.T) {
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		situations := stats.Situations()
+
+		for _, s := range situations {
+			for _, timeouts := range []int{s.Before.HomeTimeouts, s.Before.VisitorTimeouts} {
+				assert.True(t, timeouts >= 0 && timeouts <= 3)
+			}
+		}
+
+		complete := false
+		for _, p := range stats.Play {
+			if p.PlayType == PlayTypeEndGame {
+				complete = true
+			}
+		}
+		if complete {
+			require.NotEmpty(t, situations)
+			last := situations[len(situations)-1]
+			assert.Equal(t, int(stats.HomeTeamStats.TotalScore), last.After.HomeScore)
+			assert.Equal(t, int(stats.VisitorTeamStats.TotalScore), last.After.VisitorScore)
+		}
+	})
+}
@@ -228,32 +228,75 @@ type StatFileGameAttributes struct {
 	ReplayOfficial StatFileOfficials `xml:",attr"`
 }
 
+// Officials returns the officials at each position, in the order the positions appear in the
+// file.
 func (a StatFileGameAttributes) Officials() []StatFileOfficial {
 	ret := []StatFileOfficial{}
-	for _, officials := range []StatFileOfficials{
-		a.Referee,
-		a.Umpire,
-		a.HeadLinesman,
-		a.DownJudge,
-		a.LineJudge,
-		a.FieldJudge,
-		a.SideJudge,
-		a.BackJudge,
-		a.ReplayOfficial,
+	for _, position := range []struct {
+		Position  OfficialPosition
+		Officials StatFileOfficials
+	}{
+		{OfficialPositionReferee, a.Referee},
+		{OfficialPositionUmpire, a.Umpire},
+		{OfficialPositionHeadLinesman, a.HeadLinesman},
+		{OfficialPositionDownJudge, a.DownJudge},
+		{OfficialPositionLineJudge, a.LineJudge},
+		{OfficialPositionFieldJudge, a.FieldJudge},
+		{OfficialPositionSideJudge, a.SideJudge},
+		{OfficialPositionBackJudge, a.BackJudge},
+		{OfficialPositionReplayOfficial, a.ReplayOfficial},
 	} {
-		ret = append(ret, officials.Officials()...)
+		for _, official := range position.Officials.Officials() {
+			official.Position = position.Position
+			ret = append(ret, official)
+		}
 	}
 	return ret
 }
 
 type StatFileOfficial struct {
-	FirstName    string
-	LastName     string
+	FirstName string
+
+	// Any names or initials between the first and last names.
+	MiddleName string
+
+	LastName string
+
+	// A generational suffix such as "Jr" or "IV", without a trailing period.
+	Suffix string
+
 	JerseyNumber string
+
+	// Only set by (StatFileGameAttributes).Officials.
+	Position OfficialPosition
+}
+
+// Name returns the official's full name, e.g. "Walt Coleman IV".
+func (o StatFileOfficial) Name() string {
+	return strings.Join(strings.Fields(strings.Join([]string{o.FirstName, o.MiddleName, o.LastName, o.Suffix}, " ")), " ")
+}
+
+// Key identifies the official across games using their first and last name and jersey number. It
+// ignores middle names and suffixes, which GSIS doesn't include consistently. A jersey number of 0
+// means the number is unknown, the same as a missing one.
+func (o StatFileOfficial) Key() string {
+	jerseyNumber := o.JerseyNumber
+	if jerseyNumber == "0" {
+		jerseyNumber = ""
+	}
+	return strings.ToLower(o.FirstName+" "+o.LastName) + " (" + jerseyNumber + ")"
 }
 
 var officialNameJerseyNumberRegexp = regexp.MustCompile(`^([^(]*)(?:\(([^)]*)\))?`)
-var nameSuffixRegexp = regexp.MustCompile(`\s*\b([IVX]+|Sr|Jr).?\s*$`)
+var nameSuffixRegexp = regexp.MustCompile(`^(?:[IVX]+|Sr|Jr)\.?$`)
+
+// Removes a suffix from the end of the names, unless it's the only name.
+func trimNameSuffix(names []string) ([]string, string) {
+	if len(names) > 1 && nameSuffixRegexp.MatchString(names[len(names)-1]) {
+		return names[:len(names)-1], strings.TrimSuffix(names[len(names)-1], ".")
+	}
+	return names, ""
+}
 
 // Makes a best-effort attempt to parse the officials. Because there's no well-defined format for
 // these, errors are ignored.
@@ -266,20 +309,41 @@ func (o StatFileOfficials) Officials() []StatFileOfficial {
 		}
 		matches := officialNameJerseyNumberRegexp.FindStringSubmatch(s)
 		official := StatFileOfficial{
-			JerseyNumber: matches[2],
+			JerseyNumber: strings.TrimSpace(matches[2]),
 		}
+		var first, last []string
 		if parts := strings.Split(matches[1], ","); len(parts) > 1 {
-			// last name first
-			official.LastName = strings.Fields(strings.TrimSpace(parts[0]))[0]
-			official.FirstName = strings.Fields(strings.TrimSpace(parts[1]))[0]
+			// last name first, e.g. "Coleman IV, Walt" or "Killens, Jr., Terry"
+			last, official.Suffix = trimNameSuffix(strings.Fields(parts[0]))
+			for _, part := range parts[1:] {
+				names := strings.Fields(part)
+				if len(parts) > 2 && len(names) == 1 && official.Suffix == "" && nameSuffixRegexp.MatchString(names[0]) {
+					official.Suffix = strings.TrimSuffix(names[0], ".")
+					continue
+				}
+				first = append(first, names...)
+			}
+			if official.Suffix == "" {
+				first, official.Suffix = trimNameSuffix(first)
+			}
 		} else {
-			// first name first
-			parts := strings.Fields(nameSuffixRegexp.ReplaceAllString(strings.TrimSpace(parts[0]), ""))
-			official.FirstName = parts[0]
-			if len(parts) > 0 {
-				official.LastName = parts[len(parts)-1]
+			// first name first, e.g. "Walt Coleman IV"
+			names, suffix := trimNameSuffix(strings.Fields(parts[0]))
+			official.Suffix = suffix
+			first, last = names, names
+			if len(names) > 1 {
+				first = names[:len(names)-1]
+				last = names[len(names)-1:]
 			}
 		}
+		if len(first) > 0 {
+			official.FirstName = first[0]
+			official.MiddleName = strings.Join(first[1:], " ")
+		}
+		official.LastName = strings.Join(last, " ")
+		if official.FirstName == "" && official.LastName == "" {
+			continue
+		}
 		ret = append(ret, official)
 	}
 	return ret
@@ -363,132 +427,9 @@ type StatFileCumeStatHeader struct {
 	GMTOffset       StringInt `xml:",attr"`
 }
 
-// https://www.nflgsis.com/gsis/documentation/Partners/StatIDs.html
-const (
-	StatIDRushingYardsMinus                 = 1
-	StatIDPuntBlocked                       = 2
-	StatIDFirstDownRushing                  = 3
-	StatIDFirstDownPassing                  = 4
-	StatIDFirstDownPenalty                  = 5
-	StatIDThirdDownAttemptConverted         = 6
-	StatIDThirdDownAttemptFailed            = 7
-	StatIDFourthDownAttemptConverted        = 8
-	StatIDFourthDownAttemptFailed           = 9
-	StatIDRushingYards                      = 10
-	StatIDRushingYardsTD                    = 11
-	StatIDRushingYardsNoRush                = 12
-	StatIDRushingYardsTDNoRush              = 13
-	StatIDPassIncomplete                    = 14
-	StatIDPassingYards                      = 15
-	StatIDPassingYardsTD                    = 16
-	StatIDPassingYardsNoPass                = 17
-	StatIDPassingYardsTDNoPass              = 18
-	StatIDInterceptionPasser                = 19
-	StatIDSackYards                         = 20
-	StatIDPassReceptionYards                = 21
-	StatIDPassReceptionYardsTD              = 22
-	StatIDPassReceptionYardsNoReception     = 23
-	StatIDPassReceptionYardsTDNoReception   = 24
-	StatIDInterceptionYards                 = 25
-	StatIDInterceptionYardsTD               = 26
-	StatIDInterceptionYardsNoInterception   = 27
-	StatIDInterceptionYardsTDNoInterception = 28
-	StatIDPuntingYards                      = 29
-	StatIDPuntInside20                      = 30
-	StatIDPuntIntoEndZone                   = 31
-	StatIDPuntWithTouchback                 = 32
-	StatIDPuntReturnYards                   = 33
-	StatIDPuntReturnYardsTD                 = 34
-	StatIDPuntReturnYardsNoReturn           = 35
-	StatIDPuntReturnYardsTDNoReturn         = 36
-	StatIDPuntOutOfBounds                   = 37
-	StatIDPuntDownedNoReturn                = 38
-	StatIDPuntFairCatch                     = 39
-	StatIDPuntTouchbackNoReturn             = 40
-	StatIDKickoffYards                      = 41
-	StatIDKickoffInside20                   = 42
-	StatIDKickoffIntoEndZone                = 43
-	StatIDKickoffWithTouchback              = 44
-	StatIDKickoffReturnYards                = 45
-	StatIDKickoffReturnYardsTD              = 46
-	StatIDKickoffReturnYardsNoReturn        = 47
-	StatIDKickoffReturnYardsTDNoReturn      = 48
-	StatIDKickoffOutOfBounds                = 49
-	StatIDKickoffFairCatch                  = 50
-	StatIDKickoffTouchback                  = 51
-	StatIDFumbleForced                      = 52
-	StatIDFumbleNotForced                   = 53
-	StatIDFumbleOutOfBounds                 = 54
-	StatIDOwnRecoveryYards                  = 55
-	StatIDOwnRecoveryYardsTD                = 56
-	StatIDOwnRecoveryYardsNoRecovery        = 57
-	StatIDOwnRecoveryYardsTDNoRecovery      = 58
-	StatIDOpponentRecoveryYards             = 59
-	StatIDOpponentRecoveryYardsTD           = 60
-	StatIDOpponentRecoveryYardsNoRecovery   = 61
-	StatIDOpponentRecoveryYardsTDNoRecovery = 62
-	StatIDMiscellaneousYards                = 63
-	StatIDMiscellaneousYardsTD              = 64
-	StatIDTimeout                           = 68
-	StatIDFieldGoalMissedYards              = 69
-	StatIDFieldGoalYards                    = 70
-	StatIDFieldGoalBlockedOffense           = 71
-	StatIDExtraPointGood                    = 72
-	StatIDExtraPointFailed                  = 73
-	StatIDExtraPointBlocked                 = 74
-	StatID2PointRushGood                    = 75
-	StatID2PointRushFailed                  = 76
-	StatID2PointPassGood                    = 77
-	StatID2PointPassFailed                  = 78
-	StatIDSoloTackle                        = 79
-	StatIDAssistedTackle                    = 80
-	StatIDHalfTackle                        = 81
-	StatIDTackleAssist                      = 82
-	StatIDSackYardsDefense                  = 83
-	StatIDHalfSackYardsDefense              = 84
-	StatIDPassDefensed                      = 85
-	StatIDPuntBlockedDefense                = 86
-	StatIDExtraPointBlockedDefense          = 87
-	StatIDFieldGoalBlockedDefense           = 88
-	StatIDSafetyDefense                     = 89
-	StatIDHalfSafetyDefense                 = 90
-	StatIDForcedFumble                      = 91
-	StatIDPenalty                           = 93
-	StatIDTackledForLoss                    = 95
-	StatIDExtraPointSafety                  = 96
-	StatID2PointRushSafety                  = 99
-	StatID2PointPassSafety                  = 100
-	StatIDKickoffKickDowned                 = 102
-	StatIDSackYardsNoSack                   = 103
-	StatID2PointPassReceptionGood           = 104
-	StatID2PointPassReceptionFailed         = 105
-	StatIDFumbleLost                        = 106
-	StatIDOwnKickoffRecovery                = 107
-	StatIDOwnKickoffRecoveryTD              = 108
-	StatIDQuarterbackHit                    = 110
-	StatIDPassLengthCompletion              = 111
-	StatIDPassLengthNoCompletion            = 112
-	StatIDYardageGainedAfterCatch           = 113
-	StatIDPassTarget                        = 115
-	StatIDTackleForLoss                     = 120
-	StatIDLongFieldGoalYards                = 201
-	StatIDExtraPointDeuce                   = 211
-	StatID2PointRushDeuce                   = 212
-	StatID2PointPassDeuce                   = 213
-	StatIDExtraPointAborted                 = 301
-	StatIDHalfTackleForLoss                 = 401
-	StatIDTackleForLossYardage              = 402
-	StatIDDefensive2PointAttempts           = 403
-	StatIDDefensive2PointConversions        = 404
-	StatIDDefensiveExtraPointAttempts       = 405
-	StatIDDefensiveExtraPointConversions    = 406
-	StatIDKickoffLength                     = 410
-	StatID2PointReturnGood                  = 420
-)
-
 type StatFilePlayStat struct {
 	PlayID        StringInt `xml:",attr"`
-	StatID        StringInt `xml:",attr"`
+	StatID        StatID    `xml:",attr"`
 	ClubCode      string    `xml:",attr"`
 	PlayerID      string    `xml:",attr"`
 	PlayerName    string    `xml:",attr"`
@@ -518,6 +459,14 @@ func (y StatYards) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	}, nil
 }
 
+func (y StatYards) MarshalJSON() ([]byte, error) {
+	value := ""
+	if y.Value != nil {
+		value = strconv.Itoa(*y.Value)
+	}
+	return json.Marshal(value)
+}
+
 func (y *StatYards) unmarshal(s string) error {
 	if s == "" {
 		return nil
@@ -536,11 +485,14 @@ func (y *StatYards) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	switch v := v.(type) {
-	case int:
-		y.Value = &v
+	case float64:
+		n := int(v)
+		y.Value = &n
 		return nil
 	case string:
 		return y.unmarshal(v)
+	case nil:
+		return nil
 	default:
 		return fmt.Errorf("unexpected string int type: %T", v)
 	}
@@ -550,73 +502,6 @@ func (y *StatYards) UnmarshalXMLAttr(attr xml.Attr) error {
 	return y.unmarshal(attr.Value)
 }
 
-type YardLine struct {
-	team   *string
-	number *int
-	raw    string
-}
-
-func (l *YardLine) Team() *string {
-	return l.team
-}
-
-func (l *YardLine) Number() int {
-	return *l.number
-}
-
-func (l *YardLine) String() string {
-	if l.number != nil {
-		if l.team != nil {
-			return fmt.Sprintf("%v %v", *(l.team), *(l.number))
-		}
-		return fmt.Sprintf("%v", *(l.number))
-	}
-	return ""
-}
-
-func (l YardLine) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
-	return xml.Attr{
-		Name:  name,
-		Value: l.raw,
-	}, nil
-}
-
-func (l *YardLine) unmarshal(s string) error {
-	if s == "" {
-		*l = YardLine{}
-		return nil
-	}
-	values := strings.Split(s, " ")
-	if len(values) > 2 {
-		return fmt.Errorf("expected <= 2 values, found %d", len(values))
-	}
-	newYardLine := YardLine{
-		raw: s,
-	}
-	if len(values) > 1 {
-		newYardLine.team = &values[0]
-	}
-	if v, err := strconv.Atoi(values[len(values)-1]); err != nil {
-		return err
-	} else {
-		newYardLine.number = &v
-	}
-	*l = newYardLine
-	return nil
-}
-
-func (l *YardLine) UnmarshalJSON(data []byte) error {
-	var s string
-	if err := json.Unmarshal(data, &s); err != nil {
-		return err
-	}
-	return l.unmarshal(s)
-}
-
-func (l *YardLine) UnmarshalXMLAttr(attr xml.Attr) error {
-	return l.unmarshal(attr.Value)
-}
-
 const (
 	PlayTypeGame              = 1
 	PlayTypePlayFromScrimmage = 2
@@ -3,11 +3,13 @@ package gsis
 import (
 	"encoding/json"
 	"encoding/xml"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,6 +17,60 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+type testGame struct {
+	name  string
+	stats *StatFile
+}
+
+var testGamesOnce sync.Once
+var testGames []testGame
+var testGamesErr error
+
+// Parses the games in the test data once, since every corpus test needs them.
+func loadTestGames() ([]testGame, error) {
+	testGamesOnce.Do(func() {
+		files, err := ioutil.ReadDir("testdata/games")
+		if err != nil {
+			testGamesErr = err
+			return
+		}
+		for _, f := range files {
+			if !f.IsDir() {
+				continue
+			}
+			buf, err := ioutil.ReadFile(filepath.Join("testdata/games", f.Name(), "GSISGameStats.xml"))
+			if err != nil {
+				testGamesErr = err
+				return
+			}
+			var stats StatFile
+			if err := xml.Unmarshal(buf, &stats); err != nil {
+				testGamesErr = fmt.Errorf("%v: %w", f.Name(), err)
+				return
+			}
+			testGames = append(testGames, testGame{name: f.Name(), stats: &stats})
+		}
+	})
+	return testGames, testGamesErr
+}
+
+// forEachTestGame runs fn in a subtest for each game in the test data. The stat files are shared
+// between tests, so fn must not modify them. The test is skipped in short mode.
+func forEachTestGame(t *testing.T, fn func(t *testing.T, name string, stats *StatFile)) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	games, err := loadTestGames()
+	require.NoError(t, err)
+	for _, game := range games {
+		game := game
+		t.Run(game.name, func(t *testing.T) {
+			fn(t, game.name, game.stats)
+		})
+	}
+}
+
 func TestStatFile(t *testing.T) {
 	f, err := os.Open("testdata/signalr-stats.json")
 	require.NoError(t, err)
@@ -23,39 +79,23 @@ func TestStatFile(t *testing.T) {
 	var stats StatFile
 	require.NoError(t, json.NewDecoder(f).Decode(&stats))
 
-	if testing.Short() {
-		t.Skip("skipping test in short mode.")
-	}
-
-	files, err := ioutil.ReadDir("testdata/games")
-	require.NoError(t, err)
-	for _, f := range files {
-		if f.IsDir() {
-			t.Run(f.Name(), func(t *testing.T) {
-				original, err := ioutil.ReadFile(filepath.Join("testdata/games", f.Name(), "GSISGameStats.xml"))
-				require.NoError(t, err)
-
-				var stats StatFile
-				require.NoError(t, xml.Unmarshal(original, &stats))
-
-				// Confirm all plays are in play sequence order
-				for idx, p := range stats.Play {
-					if idx == 0 {
-						continue
-					}
-					previousPlaySeq := stats.Play[idx-1].PlaySeq
-					assert.Greater(t, float64(p.PlaySeq), float64(previousPlaySeq))
-				}
-
-				// Confirm that the files round-trip correctly
-				buf, err := xml.Marshal(stats)
-				require.NoError(t, err)
-				var roundTripped StatFile
-				require.NoError(t, xml.Unmarshal(buf, &roundTripped))
-				assert.Equal(t, stats, roundTripped)
-			})
+	forEachTestGame(t, func(t *testing.T, name string, stats *StatFile) {
+		// Confirm all plays are in play sequence order
+		for idx, p := range stats.Play {
+			if idx == 0 {
+				continue
+			}
+			previousPlaySeq := stats.Play[idx-1].PlaySeq
+			assert.Greater(t, float64(p.PlaySeq), float64(previousPlaySeq))
 		}
-	}
+
+		// Confirm that the files round-trip correctly
+		buf, err := xml.Marshal(stats)
+		require.NoError(t, err)
+		var roundTripped StatFile
+		require.NoError(t, xml.Unmarshal(buf, &roundTripped))
+		assert.Equal(t, *stats, roundTripped)
+	})
 }
 
 func TestStatFileOfficials(t *testing.T) {
@@ -72,6 +112,7 @@ func TestStatFileOfficials(t *testing.T) {
 			{
 				FirstName:    "Walt",
 				LastName:     "Coleman",
+				Suffix:       "IV",
 				JerseyNumber: "65",
 			},
 		},
@@ -79,9 +120,48 @@ func TestStatFileOfficials(t *testing.T) {
 			{
 				FirstName:    "Walt",
 				LastName:     "Coleman",
+				Suffix:       "IV",
 				JerseyNumber: "65",
 			},
 		},
+		"Coleman, Walt IV (87)": []StatFileOfficial{
+			{
+				FirstName:    "Walt",
+				LastName:     "Coleman",
+				Suffix:       "IV",
+				JerseyNumber: "87",
+			},
+		},
+		"Killens, Jr., Terry (77)": []StatFileOfficial{
+			{
+				FirstName:    "Terry",
+				LastName:     "Killens",
+				Suffix:       "Jr",
+				JerseyNumber: "77",
+			},
+		},
+		"Clark, V. Land (130)": []StatFileOfficial{
+			{
+				FirstName:    "V.",
+				MiddleName:   "Land",
+				LastName:     "Clark",
+				JerseyNumber: "130",
+			},
+		},
+		"John T. Smith": []StatFileOfficial{
+			{
+				FirstName:  "John",
+				MiddleName: "T.",
+				LastName:   "Smith",
+			},
+		},
+		"Smith,Shawn(14)": []StatFileOfficial{
+			{
+				FirstName:    "Shawn",
+				LastName:     "Smith",
+				JerseyNumber: "14",
+			},
+		},
 		"Walker, Jabir (26)": []StatFileOfficial{
 			{
 				FirstName:    "Jabir",
@@ -104,6 +184,31 @@ func TestStatFileOfficials(t *testing.T) {
 	}
 }
 
+func TestStatFileOfficial(t *testing.T) {
+	official := StatFileOfficials("Coleman IV, Walt (65)").Officials()[0]
+	assert.Equal(t, "Walt Coleman IV", official.Name())
+	assert.Equal(t, official.Key(), StatFileOfficials("Walt Coleman (65)").Officials()[0].Key())
+	assert.NotEqual(t, official.Key(), StatFileOfficials("Walt Coleman IV (87)").Officials()[0].Key())
+	assert.Equal(t, StatFileOfficials("Saleem Choudhry").Officials()[0].Key(), StatFileOfficials("Saleem Choudhry (0)").Officials()[0].Key())
+}
+
+func TestStatFileGameAttributes_Officials(t *testing.T) {
+	officials := StatFileGameAttributes{
+		Referee:        "Hussey, John (35)",
+		Umpire:         "Michalek, Tony (115)",
+		DownJudge:      "Codey, Kevin (16)",
+		ReplayOfficial: "Frantz, Earnie & Valenti, Terri",
+	}.Officials()
+	require.Len(t, officials, 5)
+	assert.Equal(t, OfficialPositionReferee, officials[0].Position)
+	assert.Equal(t, "Hussey", officials[0].LastName)
+	assert.Equal(t, OfficialPositionUmpire, officials[1].Position)
+	assert.Equal(t, OfficialPositionDownJudge, officials[2].Position)
+	assert.Equal(t, OfficialPositionReplayOfficial, officials[3].Position)
+	assert.Equal(t, OfficialPositionReplayOfficial, officials[4].Position)
+	assert.Equal(t, "Valenti", officials[4].LastName)
+}
+
 func TestStatFile_Update(t *testing.T) {
 	statFile := &StatFile{}
 	for i := 1; i <= 271; i++ {
@@ -0,0 +1,431 @@
+package gsis
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+)
+
+// A GSIS stat ID. Each PlayStat credits a player or team with one of these.
+//
+// https://www.nflgsis.com/gsis/documentation/Partners/StatIDs.html
+type StatID int
+
+const (
+	StatIDRushingYardsMinus                 StatID = 1
+	StatIDPuntBlocked                       StatID = 2
+	StatIDFirstDownRushing                  StatID = 3
+	StatIDFirstDownPassing                  StatID = 4
+	StatIDFirstDownPenalty                  StatID = 5
+	StatIDThirdDownAttemptConverted         StatID = 6
+	StatIDThirdDownAttemptFailed            StatID = 7
+	StatIDFourthDownAttemptConverted        StatID = 8
+	StatIDFourthDownAttemptFailed           StatID = 9
+	StatIDRushingYards                      StatID = 10
+	StatIDRushingYardsTD                    StatID = 11
+	StatIDRushingYardsNoRush                StatID = 12
+	StatIDRushingYardsTDNoRush              StatID = 13
+	StatIDPassIncomplete                    StatID = 14
+	StatIDPassingYards                      StatID = 15
+	StatIDPassingYardsTD                    StatID = 16
+	StatIDPassingYardsNoPass                StatID = 17
+	StatIDPassingYardsTDNoPass              StatID = 18
+	StatIDInterceptionPasser                StatID = 19
+	StatIDSackYards                         StatID = 20
+	StatIDPassReceptionYards                StatID = 21
+	StatIDPassReceptionYardsTD              StatID = 22
+	StatIDPassReceptionYardsNoReception     StatID = 23
+	StatIDPassReceptionYardsTDNoReception   StatID = 24
+	StatIDInterceptionYards                 StatID = 25
+	StatIDInterceptionYardsTD               StatID = 26
+	StatIDInterceptionYardsNoInterception   StatID = 27
+	StatIDInterceptionYardsTDNoInterception StatID = 28
+	StatIDPuntingYards                      StatID = 29
+	StatIDPuntInside20                      StatID = 30
+	StatIDPuntIntoEndZone                   StatID = 31
+	StatIDPuntWithTouchback                 StatID = 32
+	StatIDPuntReturnYards                   StatID = 33
+	StatIDPuntReturnYardsTD                 StatID = 34
+	StatIDPuntReturnYardsNoReturn           StatID = 35
+	StatIDPuntReturnYardsTDNoReturn         StatID = 36
+	StatIDPuntOutOfBounds                   StatID = 37
+	StatIDPuntDownedNoReturn                StatID = 38
+	StatIDPuntFairCatch                     StatID = 39
+	StatIDPuntTouchbackNoReturn             StatID = 40
+	StatIDKickoffYards                      StatID = 41
+	StatIDKickoffInside20                   StatID = 42
+	StatIDKickoffIntoEndZone                StatID = 43
+	StatIDKickoffWithTouchback              StatID = 44
+	StatIDKickoffReturnYards                StatID = 45
+	StatIDKickoffReturnYardsTD              StatID = 46
+	StatIDKickoffReturnYardsNoReturn        StatID = 47
+	StatIDKickoffReturnYardsTDNoReturn      StatID = 48
+	StatIDKickoffOutOfBounds                StatID = 49
+	StatIDKickoffFairCatch                  StatID = 50
+	StatIDKickoffTouchback                  StatID = 51
+	StatIDFumbleForced                      StatID = 52
+	StatIDFumbleNotForced                   StatID = 53
+	StatIDFumbleOutOfBounds                 StatID = 54
+	StatIDOwnRecoveryYards                  StatID = 55
+	StatIDOwnRecoveryYardsTD                StatID = 56
+	StatIDOwnRecoveryYardsNoRecovery        StatID = 57
+	StatIDOwnRecoveryYardsTDNoRecovery      StatID = 58
+	StatIDOpponentRecoveryYards             StatID = 59
+	StatIDOpponentRecoveryYardsTD           StatID = 60
+	StatIDOpponentRecoveryYardsNoRecovery   StatID = 61
+	StatIDOpponentRecoveryYardsTDNoRecovery StatID = 62
+	StatIDMiscellaneousYards                StatID = 63
+	StatIDMiscellaneousYardsTD              StatID = 64
+	StatIDTimeout                           StatID = 68
+	StatIDFieldGoalMissedYards              StatID = 69
+	StatIDFieldGoalYards                    StatID = 70
+	StatIDFieldGoalBlockedOffense           StatID = 71
+	StatIDExtraPointGood                    StatID = 72
+	StatIDExtraPointFailed                  StatID = 73
+	StatIDExtraPointBlocked                 StatID = 74
+	StatID2PointRushGood                    StatID = 75
+	StatID2PointRushFailed                  StatID = 76
+	StatID2PointPassGood                    StatID = 77
+	StatID2PointPassFailed                  StatID = 78
+	StatIDSoloTackle                        StatID = 79
+	StatIDAssistedTackle                    StatID = 80
+	StatIDHalfTackle                        StatID = 81
+	StatIDTackleAssist                      StatID = 82
+	StatIDSackYardsDefense                  StatID = 83
+	StatIDHalfSackYardsDefense              StatID = 84
+	StatIDPassDefensed                      StatID = 85
+	StatIDPuntBlockedDefense                StatID = 86
+	StatIDExtraPointBlockedDefense          StatID = 87
+	StatIDFieldGoalBlockedDefense           StatID = 88
+	StatIDSafetyDefense                     StatID = 89
+	StatIDHalfSafetyDefense                 StatID = 90
+	StatIDForcedFumble                      StatID = 91
+	StatIDPenalty                           StatID = 93
+	StatIDTackledForLoss                    StatID = 95
+	StatIDExtraPointSafety                  StatID = 96
+	StatID2PointRushSafety                  StatID = 99
+	StatID2PointPassSafety                  StatID = 100
+	StatIDKickoffKickDowned                 StatID = 102
+	StatIDSackYardsNoSack                   StatID = 103
+	StatID2PointPassReceptionGood           StatID = 104
+	StatID2PointPassReceptionFailed         StatID = 105
+	StatIDFumbleLost                        StatID = 106
+	StatIDOwnKickoffRecovery                StatID = 107
+	StatIDOwnKickoffRecoveryTD              StatID = 108
+	StatIDQuarterbackHit                    StatID = 110
+	StatIDPassLengthCompletion              StatID = 111
+	StatIDPassLengthNoCompletion            StatID = 112
+	StatIDYardageGainedAfterCatch           StatID = 113
+	StatIDPassTarget                        StatID = 115
+	StatIDTackleForLoss                     StatID = 120
+	StatIDLongFieldGoalYards                StatID = 201
+	StatIDExtraPointDeuce                   StatID = 211
+	StatID2PointRushDeuce                   StatID = 212
+	StatID2PointPassDeuce                   StatID = 213
+	StatIDExtraPointAborted                 StatID = 301
+	StatIDHalfTackleForLoss                 StatID = 401
+	StatIDTackleForLossYardage              StatID = 402
+	StatIDDefensive2PointAttempts           StatID = 403
+	StatIDDefensive2PointConversions        StatID = 404
+	StatIDDefensiveExtraPointAttempts       StatID = 405
+	StatIDDefensiveExtraPointConversions    StatID = 406
+	StatIDKickoffLength                     StatID = 410
+	StatID2PointReturnGood                  StatID = 420
+)
+
+// Signalr sends stat IDs as strings.
+func (id *StatID) UnmarshalJSON(data []byte) error {
+	var v StringInt
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*id = StatID(v)
+	return nil
+}
+
+type StatCategory string
+
+const (
+	StatCategoryPassing   StatCategory = "PASSING"
+	StatCategoryRushing   StatCategory = "RUSHING"
+	StatCategoryReceiving StatCategory = "RECEIVING"
+	StatCategoryDefense   StatCategory = "DEFENSE"
+	StatCategoryKicking   StatCategory = "KICKING"
+	StatCategoryReturns   StatCategory = "RETURNS"
+	StatCategoryFumbles   StatCategory = "FUMBLES"
+	StatCategoryPenalty   StatCategory = "PENALTY"
+
+	// Stats credited to a team rather than a player, such as first downs.
+	StatCategoryTeam StatCategory = "TEAM"
+)
+
+// The unit a stat is credited to.
+type StatSide string
+
+const (
+	StatSideOffense      StatSide = "OFFENSE"
+	StatSideDefense      StatSide = "DEFENSE"
+	StatSideSpecialTeams StatSide = "SPECIAL_TEAMS"
+
+	// For stats that can be credited to either side, such as penalties.
+	StatSideNone StatSide = ""
+)
+
+// What a stat's Yards attribute means.
+type StatYardsMeaning string
+
+const (
+	// The stat doesn't have yards.
+	StatYardsMeaningNone StatYardsMeaning = ""
+
+	// Yards gained. Negative for a loss.
+	StatYardsMeaningGain StatYardsMeaning = "GAIN"
+
+	// Yards lost on a sack or tackle for a loss. These are negative, except for
+	// StatIDTackleForLossYardage.
+	StatYardsMeaningLoss StatYardsMeaning = "LOSS"
+
+	// The distance of a kick, or of a field goal attempt.
+	StatYardsMeaningKickDistance StatYardsMeaning = "KICK_DISTANCE"
+
+	// Yards gained on a return.
+	StatYardsMeaningReturn StatYardsMeaning = "RETURN"
+
+	// The yards a penalty was enforced for.
+	StatYardsMeaningPenalty StatYardsMeaning = "PENALTY"
+
+	// The distance a pass travelled past the line of scrimmage.
+	StatYardsMeaningPassLength StatYardsMeaning = "PASS_LENGTH"
+)
+
+type StatIDInfo struct {
+	ID StatID
+
+	// The name GSIS uses for the stat, e.g. "Rushing Yards, TD".
+	Name string
+
+	Category StatCategory
+	Side     StatSide
+	Yards    StatYardsMeaning
+
+	// Credited for a touchdown. A touchdown is usually credited to more than one player.
+	Touchdown bool
+
+	// Credited for an interception or lost fumble.
+	Turnover bool
+
+	// Credited for a first down.
+	FirstDown bool
+}
+
+var statIDInfo = map[StatID]*StatIDInfo{
+	StatIDRushingYardsMinus:                 {ID: StatIDRushingYardsMinus, Name: "Rushing Yards Minus", Category: StatCategoryRushing, Side: StatSideOffense, Yards: StatYardsMeaningGain},
+	StatIDPuntBlocked:                       {ID: StatIDPuntBlocked, Name: "Punt Blocked (Offense)", Category: StatCategoryKicking, Side: StatSideSpecialTeams},
+	StatIDFirstDownRushing:                  {ID: StatIDFirstDownRushing, Name: "1st Down Rushing", Category: StatCategoryTeam, Side: StatSideOffense, FirstDown: true},
+	StatIDFirstDownPassing:                  {ID: StatIDFirstDownPassing, Name: "1st Down Passing", Category: StatCategoryTeam, Side: StatSideOffense, FirstDown: true},
+	StatIDFirstDownPenalty:                  {ID: StatIDFirstDownPenalty, Name: "1st Down Penalty", Category: StatCategoryTeam, Side: StatSideOffense, FirstDown: true},
+	StatIDThirdDownAttemptConverted:         {ID: StatIDThirdDownAttemptConverted, Name: "3rd Down Attempt Converted", Category: StatCategoryTeam, Side: StatSideOffense},
+	StatIDThirdDownAttemptFailed:            {ID: StatIDThirdDownAttemptFailed, Name: "3rd Down Attempt Failed", Category: StatCategoryTeam, Side: StatSideOffense},
+	StatIDFourthDownAttemptConverted:        {ID: StatIDFourthDownAttemptConverted, Name: "4th Down Attempt Converted", Category: StatCategoryTeam, Side: StatSideOffense},
+	StatIDFourthDownAttemptFailed:           {ID: StatIDFourthDownAttemptFailed, Name: "4th Down Attempt Failed", Category: StatCategoryTeam, Side: StatSideOffense},
+	StatIDRushingYards:                      {ID: StatIDRushingYards, Name: "Rushing Yards", Category: StatCategoryRushing, Side: StatSideOffense, Yards: StatYardsMeaningGain},
+	StatIDRushingYardsTD:                    {ID: StatIDRushingYardsTD, Name: "Rushing Yards, TD", Category: StatCategoryRushing, Side: StatSideOffense, Yards: StatYardsMeaningGain, Touchdown: true},
+	StatIDRushingYardsNoRush:                {ID: StatIDRushingYardsNoRush, Name: "Rushing Yards, No Rush", Category: StatCategoryRushing, Side: StatSideOffense, Yards: StatYardsMeaningGain},
+	StatIDRushingYardsTDNoRush:              {ID: StatIDRushingYardsTDNoRush, Name: "Rushing Yards, TD, No Rush", Category: StatCategoryRushing, Side: StatSideOffense, Yards: StatYardsMeaningGain, Touchdown: true},
+	StatIDPassIncomplete:                    {ID: StatIDPassIncomplete, Name: "Pass Incomplete", Category: StatCategoryPassing, Side: StatSideOffense},
+	StatIDPassingYards:                      {ID: StatIDPassingYards, Name: "Passing Yards", Category: StatCategoryPassing, Side: StatSideOffense, Yards: StatYardsMeaningGain},
+	StatIDPassingYardsTD:                    {ID: StatIDPassingYardsTD, Name: "Passing Yards, TD", Category: StatCategoryPassing, Side: StatSideOffense, Yards: StatYardsMeaningGain, Touchdown: true},
+	StatIDPassingYardsNoPass:                {ID: StatIDPassingYardsNoPass, Name: "Passing Yards, No Pass", Category: StatCategoryPassing, Side: StatSideOffense, Yards: StatYardsMeaningGain},
+	StatIDPassingYardsTDNoPass:              {ID: StatIDPassingYardsTDNoPass, Name: "Passing Yards, TD, No Pass", Category: StatCategoryPassing, Side: StatSideOffense, Yards: StatYardsMeaningGain, Touchdown: true},
+	StatIDInterceptionPasser:                {ID: StatIDInterceptionPasser, Name: "Interception (by Passer)", Category: StatCategoryPassing, Side: StatSideOffense, Turnover: true},
+	StatIDSackYards:                         {ID: StatIDSackYards, Name: "Sack Yards (Offense)", Category: StatCategoryPassing, Side: StatSideOffense, Yards: StatYardsMeaningLoss},
+	StatIDPassReceptionYards:                {ID: StatIDPassReceptionYards, Name: "Pass Reception Yards", Category: StatCategoryReceiving, Side: StatSideOffense, Yards: StatYardsMeaningGain},
+	StatIDPassReceptionYardsTD:              {ID: StatIDPassReceptionYardsTD, Name: "Pass Reception Yards, TD", Category: StatCategoryReceiving, Side: StatSideOffense, Yards: StatYardsMeaningGain, Touchdown: true},
+	StatIDPassReceptionYardsNoReception:     {ID: StatIDPassReceptionYardsNoReception, Name: "Pass Reception Yards, No Reception", Category: StatCategoryReceiving, Side: StatSideOffense, Yards: StatYardsMeaningGain},
+	StatIDPassReceptionYardsTDNoReception:   {ID: StatIDPassReceptionYardsTDNoReception, Name: "Pass Reception Yards, TD, No Reception", Category: StatCategoryReceiving, Side: StatSideOffense, Yards: StatYardsMeaningGain, Touchdown: true},
+	StatIDInterceptionYards:                 {ID: StatIDInterceptionYards, Name: "Interception Yards", Category: StatCategoryDefense, Side: StatSideDefense, Yards: StatYardsMeaningReturn, Turnover: true},
+	StatIDInterceptionYardsTD:               {ID: StatIDInterceptionYardsTD, Name: "Interception Yards, TD", Category: StatCategoryDefense, Side: StatSideDefense, Yards: StatYardsMeaningReturn, Touchdown: true, Turnover: true},
+	StatIDInterceptionYardsNoInterception:   {ID: StatIDInterceptionYardsNoInterception, Name: "Interception Yards, No Interception", Category: StatCategoryDefense, Side: StatSideDefense, Yards: StatYardsMeaningReturn},
+	StatIDInterceptionYardsTDNoInterception: {ID: StatIDInterceptionYardsTDNoInterception, Name: "Interception Yards, TD, No Interception", Category: StatCategoryDefense, Side: StatSideDefense, Yards: StatYardsMeaningReturn, Touchdown: true},
+	StatIDPuntingYards:                      {ID: StatIDPuntingYards, Name: "Punting Yards", Category: StatCategoryKicking, Side: StatSideSpecialTeams, Yards: StatYardsMeaningKickDistance},
+	StatIDPuntInside20:                      {ID: StatIDPuntInside20, Name: "Punt Inside 20", Category: StatCategoryKicking, Side: StatSideSpecialTeams},
+	StatIDPuntIntoEndZone:                   {ID: StatIDPuntIntoEndZone, Name: "Punt Into End Zone", Category: StatCategoryKicking, Side: StatSideSpecialTeams, Yards: StatYardsMeaningKickDistance},
+	StatIDPuntWithTouchback:                 {ID: StatIDPuntWithTouchback, Name: "Punt With Touchback", Category: StatCategoryKicking, Side: StatSideSpecialTeams, Yards: StatYardsMeaningKickDistance},
+	StatIDPuntReturnYards:                   {ID: StatIDPuntReturnYards, Name: "Punt Return Yards", Category: StatCategoryReturns, Side: StatSideSpecialTeams, Yards: StatYardsMeaningReturn},
+	StatIDPuntReturnYardsTD:                 {ID: StatIDPuntReturnYardsTD, Name: "Punt Return Yards, TD", Category: StatCategoryReturns, Side: StatSideSpecialTeams, Yards: StatYardsMeaningReturn, Touchdown: true},
+	StatIDPuntReturnYardsNoReturn:           {ID: StatIDPuntReturnYardsNoReturn, Name: "Punt Return Yards, No Return", Category: StatCategoryReturns, Side: StatSideSpecialTeams, Yards: StatYardsMeaningReturn},
+	StatIDPuntReturnYardsTDNoReturn:         {ID: StatIDPuntReturnYardsTDNoReturn, Name: "Punt Return Yards, TD, No Return", Category: StatCategoryReturns, Side: StatSideSpecialTeams, Yards: StatYardsMeaningReturn, Touchdown: true},
+	StatIDPuntOutOfBounds:                   {ID: StatIDPuntOutOfBounds, Name: "Punt Out Of Bounds", Category: StatCategoryKicking, Side: StatSideSpecialTeams},
+	StatIDPuntDownedNoReturn:                {ID: StatIDPuntDownedNoReturn, Name: "Punt Downed (No Return)", Category: StatCategoryKicking, Side: StatSideSpecialTeams},
+	StatIDPuntFairCatch:                     {ID: StatIDPuntFairCatch, Name: "Punt - Fair Catch", Category: StatCategoryReturns, Side: StatSideSpecialTeams},
+	StatIDPuntTouchbackNoReturn:             {ID: StatIDPuntTouchbackNoReturn, Name: "Punt - Touchback (No Return)", Category: StatCategoryReturns, Side: StatSideSpecialTeams},
+	StatIDKickoffYards:                      {ID: StatIDKickoffYards, Name: "Kickoff Yards", Category: StatCategoryKicking, Side: StatSideSpecialTeams, Yards: StatYardsMeaningKickDistance},
+	StatIDKickoffInside20:                   {ID: StatIDKickoffInside20, Name: "Kickoff Inside 20", Category: StatCategoryKicking, Side: StatSideSpecialTeams},
+	StatIDKickoffIntoEndZone:                {ID: StatIDKickoffIntoEndZone, Name: "Kickoff Into End Zone", Category: StatCategoryKicking, Side: StatSideSpecialTeams, Yards: StatYardsMeaningKickDistance},
+	StatIDKickoffWithTouchback:              {ID: StatIDKickoffWithTouchback, Name: "Kickoff With Touchback", Category: StatCategoryKicking, Side: StatSideSpecialTeams, Yards: StatYardsMeaningKickDistance},
+	StatIDKickoffReturnYards:                {ID: StatIDKickoffReturnYards, Name: "Kickoff Return Yards", Category: StatCategoryReturns, Side: StatSideSpecialTeams, Yards: StatYardsMeaningReturn},
+	StatIDKickoffReturnYardsTD:              {ID: StatIDKickoffReturnYardsTD, Name: "Kickoff Return Yards, TD", Category: StatCategoryReturns, Side: StatSideSpecialTeams, Yards: StatYardsMeaningReturn, Touchdown: true},
+	StatIDKickoffReturnYardsNoReturn:        {ID: StatIDKickoffReturnYardsNoReturn, Name: "Kickoff Return Yards, No Return", Category: StatCategoryReturns, Side: StatSideSpecialTeams, Yards: StatYardsMeaningReturn},
+	StatIDKickoffReturnYardsTDNoReturn:      {ID: StatIDKickoffReturnYardsTDNoReturn, Name: "Kickoff Return Yards, TD, No Return", Category: StatCategoryReturns, Side: StatSideSpecialTeams, Yards: StatYardsMeaningReturn, Touchdown: true},
+	StatIDKickoffOutOfBounds:                {ID: StatIDKickoffOutOfBounds, Name: "Kickoff Out Of Bounds", Category: StatCategoryKicking, Side: StatSideSpecialTeams},
+	StatIDKickoffFairCatch:                  {ID: StatIDKickoffFairCatch, Name: "Kickoff - Fair Catch", Category: StatCategoryReturns, Side: StatSideSpecialTeams},
+	StatIDKickoffTouchback:                  {ID: StatIDKickoffTouchback, Name: "Kickoff - Touchback", Category: StatCategoryReturns, Side: StatSideSpecialTeams},
+	StatIDFumbleForced:                      {ID: StatIDFumbleForced, Name: "Fumble - Forced", Category: StatCategoryFumbles, Side: StatSideOffense},
+	StatIDFumbleNotForced:                   {ID: StatIDFumbleNotForced, Name: "Fumble - Not Forced", Category: StatCategoryFumbles, Side: StatSideOffense},
+	StatIDFumbleOutOfBounds:                 {ID: StatIDFumbleOutOfBounds, Name: "Fumble - Out Of Bounds", Category: StatCategoryFumbles, Side: StatSideOffense},
+	StatIDOwnRecoveryYards:                  {ID: StatIDOwnRecoveryYards, Name: "Own Recovery Yards", Category: StatCategoryFumbles, Side: StatSideOffense, Yards: StatYardsMeaningReturn},
+	StatIDOwnRecoveryYardsTD:                {ID: StatIDOwnRecoveryYardsTD, Name: "Own Recovery Yards, TD", Category: StatCategoryFumbles, Side: StatSideOffense, Yards: StatYardsMeaningReturn, Touchdown: true},
+	StatIDOwnRecoveryYardsNoRecovery:        {ID: StatIDOwnRecoveryYardsNoRecovery, Name: "Own Recovery Yards, No Recovery", Category: StatCategoryFumbles, Side: StatSideOffense, Yards: StatYardsMeaningReturn},
+	StatIDOwnRecoveryYardsTDNoRecovery:      {ID: StatIDOwnRecoveryYardsTDNoRecovery, Name: "Own Recovery Yards, TD, No Recovery", Category: StatCategoryFumbles, Side: StatSideOffense, Yards: StatYardsMeaningReturn, Touchdown: true},
+	StatIDOpponentRecoveryYards:             {ID: StatIDOpponentRecoveryYards, Name: "Opponent Recovery Yards", Category: StatCategoryDefense, Side: StatSideDefense, Yards: StatYardsMeaningReturn, Turnover: true},
+	StatIDOpponentRecoveryYardsTD:           {ID: StatIDOpponentRecoveryYardsTD, Name: "Opponent Recovery Yards, TD", Category: StatCategoryDefense, Side: StatSideDefense, Yards: StatYardsMeaningReturn, Touchdown: true, Turnover: true},
+	StatIDOpponentRecoveryYardsNoRecovery:   {ID: StatIDOpponentRecoveryYardsNoRecovery, Name: "Opponent Recovery Yards, No Recovery", Category: StatCategoryDefense, Side: StatSideDefense, Yards: StatYardsMeaningReturn},
+	StatIDOpponentRecoveryYardsTDNoRecovery: {ID: StatIDOpponentRecoveryYardsTDNoRecovery, Name: "Opponent Recovery Yards, TD, No Recovery", Category: StatCategoryDefense, Side: StatSideDefense, Yards: StatYardsMeaningReturn, Touchdown: true},
+	StatIDMiscellaneousYards:                {ID: StatIDMiscellaneousYards, Name: "Miscellaneous Yards", Category: StatCategoryReturns, Yards: StatYardsMeaningReturn},
+	StatIDMiscellaneousYardsTD:              {ID: StatIDMiscellaneousYardsTD, Name: "Miscellaneous Yards, TD", Category: StatCategoryReturns, Yards: StatYardsMeaningReturn, Touchdown: true},
+	StatIDTimeout:                           {ID: StatIDTimeout, Name: "Timeout", Category: StatCategoryTeam},
+	StatIDFieldGoalMissedYards:              {ID: StatIDFieldGoalMissedYards, Name: "Field Goal Missed Yards", Category: StatCategoryKicking, Side: StatSideSpecialTeams, Yards: StatYardsMeaningKickDistance},
+	StatIDFieldGoalYards:                    {ID: StatIDFieldGoalYards, Name: "Field Goal Yards", Category: StatCategoryKicking, Side: StatSideSpecialTeams, Yards: StatYardsMeaningKickDistance},
+	StatIDFieldGoalBlockedOffense:           {ID: StatIDFieldGoalBlockedOffense, Name: "Field Goal Blocked (Offense)", Category: StatCategoryKicking, Side: StatSideSpecialTeams, Yards: StatYardsMeaningKickDistance},
+	StatIDExtraPointGood:                    {ID: StatIDExtraPointGood, Name: "Extra Point - Good", Category: StatCategoryKicking, Side: StatSideSpecialTeams},
+	StatIDExtraPointFailed:                  {ID: StatIDExtraPointFailed, Name: "Extra Point - Failed", Category: StatCategoryKicking, Side: StatSideSpecialTeams},
+	StatIDExtraPointBlocked:                 {ID: StatIDExtraPointBlocked, Name: "Extra Point - Blocked", Category: StatCategoryKicking, Side: StatSideSpecialTeams},
+	StatID2PointRushGood:                    {ID: StatID2PointRushGood, Name: "2 Point Rush - Good", Category: StatCategoryRushing, Side: StatSideOffense},
+	StatID2PointRushFailed:                  {ID: StatID2PointRushFailed, Name: "2 Point Rush - Failed", Category: StatCategoryRushing, Side: StatSideOffense},
+	StatID2PointPassGood:                    {ID: StatID2PointPassGood, Name: "2 Point Pass - Good", Category: StatCategoryPassing, Side: StatSideOffense},
+	StatID2PointPassFailed:                  {ID: StatID2PointPassFailed, Name: "2 Point Pass - Failed", Category: StatCategoryPassing, Side: StatSideOffense},
+	StatIDSoloTackle:                        {ID: StatIDSoloTackle, Name: "Solo Tackle", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDAssistedTackle:                    {ID: StatIDAssistedTackle, Name: "Assisted Tackle", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDHalfTackle:                        {ID: StatIDHalfTackle, Name: "1/2 Tackle", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDTackleAssist:                      {ID: StatIDTackleAssist, Name: "Tackle Assist", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDSackYardsDefense:                  {ID: StatIDSackYardsDefense, Name: "Sack Yards (Defense)", Category: StatCategoryDefense, Side: StatSideDefense, Yards: StatYardsMeaningLoss},
+	StatIDHalfSackYardsDefense:              {ID: StatIDHalfSackYardsDefense, Name: "1/2 Sack Yards (Defense)", Category: StatCategoryDefense, Side: StatSideDefense, Yards: StatYardsMeaningLoss},
+	StatIDPassDefensed:                      {ID: StatIDPassDefensed, Name: "Pass Defensed", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDPuntBlockedDefense:                {ID: StatIDPuntBlockedDefense, Name: "Punt Blocked (Defense)", Category: StatCategoryDefense, Side: StatSideSpecialTeams},
+	StatIDExtraPointBlockedDefense:          {ID: StatIDExtraPointBlockedDefense, Name: "Extra Point Blocked (Defense)", Category: StatCategoryDefense, Side: StatSideSpecialTeams},
+	StatIDFieldGoalBlockedDefense:           {ID: StatIDFieldGoalBlockedDefense, Name: "Field Goal Blocked (Defense)", Category: StatCategoryDefense, Side: StatSideSpecialTeams},
+	StatIDSafetyDefense:                     {ID: StatIDSafetyDefense, Name: "Safety (Defense)", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDHalfSafetyDefense:                 {ID: StatIDHalfSafetyDefense, Name: "1/2 Safety (Defense)", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDForcedFumble:                      {ID: StatIDForcedFumble, Name: "Forced Fumble (Defense)", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDPenalty:                           {ID: StatIDPenalty, Name: "Penalty", Category: StatCategoryPenalty, Yards: StatYardsMeaningPenalty},
+	StatIDTackledForLoss:                    {ID: StatIDTackledForLoss, Name: "Tackled for a Loss", Category: StatCategoryTeam, Side: StatSideOffense, Yards: StatYardsMeaningLoss},
+	StatIDExtraPointSafety:                  {ID: StatIDExtraPointSafety, Name: "Extra Point - Safety", Category: StatCategoryTeam},
+	StatID2PointRushSafety:                  {ID: StatID2PointRushSafety, Name: "2 Point Rush - Safety", Category: StatCategoryTeam},
+	StatID2PointPassSafety:                  {ID: StatID2PointPassSafety, Name: "2 Point Pass - Safety", Category: StatCategoryTeam},
+	StatIDKickoffKickDowned:                 {ID: StatIDKickoffKickDowned, Name: "Kickoff - Kick Downed", Category: StatCategoryKicking, Side: StatSideSpecialTeams},
+	StatIDSackYardsNoSack:                   {ID: StatIDSackYardsNoSack, Name: "Sack Yards (Offense), No Sack", Category: StatCategoryPassing, Side: StatSideOffense, Yards: StatYardsMeaningLoss},
+	StatID2PointPassReceptionGood:           {ID: StatID2PointPassReceptionGood, Name: "2 Point Pass Reception - Good", Category: StatCategoryReceiving, Side: StatSideOffense},
+	StatID2PointPassReceptionFailed:         {ID: StatID2PointPassReceptionFailed, Name: "2 Point Pass Reception - Failed", Category: StatCategoryReceiving, Side: StatSideOffense},
+	StatIDFumbleLost:                        {ID: StatIDFumbleLost, Name: "Fumble Lost", Category: StatCategoryFumbles, Side: StatSideOffense, Turnover: true},
+	StatIDOwnKickoffRecovery:                {ID: StatIDOwnKickoffRecovery, Name: "Own Kickoff Recovery", Category: StatCategoryReturns, Side: StatSideSpecialTeams, Yards: StatYardsMeaningReturn},
+	StatIDOwnKickoffRecoveryTD:              {ID: StatIDOwnKickoffRecoveryTD, Name: "Own Kickoff Recovery, TD", Category: StatCategoryReturns, Side: StatSideSpecialTeams, Yards: StatYardsMeaningReturn, Touchdown: true},
+	StatIDQuarterbackHit:                    {ID: StatIDQuarterbackHit, Name: "Quarterback Hit", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDPassLengthCompletion:              {ID: StatIDPassLengthCompletion, Name: "Pass Length, Completion", Category: StatCategoryPassing, Side: StatSideOffense, Yards: StatYardsMeaningPassLength},
+	StatIDPassLengthNoCompletion:            {ID: StatIDPassLengthNoCompletion, Name: "Pass Length, No Completion", Category: StatCategoryPassing, Side: StatSideOffense, Yards: StatYardsMeaningPassLength},
+	StatIDYardageGainedAfterCatch:           {ID: StatIDYardageGainedAfterCatch, Name: "Yardage Gained After the Catch", Category: StatCategoryReceiving, Side: StatSideOffense, Yards: StatYardsMeaningGain},
+	StatIDPassTarget:                        {ID: StatIDPassTarget, Name: "Pass Target", Category: StatCategoryReceiving, Side: StatSideOffense},
+	StatIDTackleForLoss:                     {ID: StatIDTackleForLoss, Name: "Tackle for a Loss", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDLongFieldGoalYards:                {ID: StatIDLongFieldGoalYards, Name: "Long Field Goal Yards", Category: StatCategoryKicking, Side: StatSideSpecialTeams, Yards: StatYardsMeaningKickDistance},
+	StatIDExtraPointDeuce:                   {ID: StatIDExtraPointDeuce, Name: "Extra Point - Deuce", Category: StatCategoryKicking, Side: StatSideSpecialTeams},
+	StatID2PointRushDeuce:                   {ID: StatID2PointRushDeuce, Name: "2 Point Rush - Deuce", Category: StatCategoryRushing, Side: StatSideOffense},
+	StatID2PointPassDeuce:                   {ID: StatID2PointPassDeuce, Name: "2 Point Pass - Deuce", Category: StatCategoryPassing, Side: StatSideOffense},
+	StatIDExtraPointAborted:                 {ID: StatIDExtraPointAborted, Name: "Extra Point - Aborted", Category: StatCategoryKicking, Side: StatSideSpecialTeams},
+	StatIDHalfTackleForLoss:                 {ID: StatIDHalfTackleForLoss, Name: "1/2 Tackle for a Loss", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDTackleForLossYardage:              {ID: StatIDTackleForLossYardage, Name: "Tackle for a Loss Yardage", Category: StatCategoryDefense, Side: StatSideDefense, Yards: StatYardsMeaningLoss},
+	StatIDDefensive2PointAttempts:           {ID: StatIDDefensive2PointAttempts, Name: "Defensive 2 Point Attempt", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDDefensive2PointConversions:        {ID: StatIDDefensive2PointConversions, Name: "Defensive 2 Point Conversion", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDDefensiveExtraPointAttempts:       {ID: StatIDDefensiveExtraPointAttempts, Name: "Defensive Extra Point Attempt", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDDefensiveExtraPointConversions:    {ID: StatIDDefensiveExtraPointConversions, Name: "Defensive Extra Point Conversion", Category: StatCategoryDefense, Side: StatSideDefense},
+	StatIDKickoffLength:                     {ID: StatIDKickoffLength, Name: "Kickoff Length", Category: StatCategoryKicking, Side: StatSideSpecialTeams, Yards: StatYardsMeaningKickDistance},
+	StatID2PointReturnGood:                  {ID: StatID2PointReturnGood, Name: "2 Point Return - Good", Category: StatCategoryDefense, Side: StatSideDefense},
+}
+
+// StatIDs returns every known stat ID in ascending order.
+func StatIDs() []StatID {
+	ret := make([]StatID, 0, len(statIDInfo))
+	for id := range statIDInfo {
+		ret = append(ret, id)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i] < ret[j]
+	})
+	return ret
+}
+
+// Info returns a copy of the stat's metadata. It returns false if the stat ID is unknown.
+func (id StatID) Info() (StatIDInfo, bool) {
+	if info, ok := statIDInfo[id]; ok {
+		return *info, true
+	}
+	return StatIDInfo{ID: id}, false
+}
+
+func (id StatID) info() StatIDInfo {
+	info, _ := id.Info()
+	return info
+}
+
+func (id StatID) String() string {
+	if info, ok := statIDInfo[id]; ok {
+		return info.Name
+	}
+	return fmt.Sprintf("StatID(%d)", int(id))
+}
+
+// Category returns the stat's category or an empty string if the stat ID is unknown.
+func (id StatID) Category() StatCategory {
+	return id.info().Category
+}
+
+func (id StatID) Side() StatSide {
+	return id.info().Side
+}
+
+// YardsMeaning returns what the Yards attribute means for the stat.
+func (id StatID) YardsMeaning() StatYardsMeaning {
+	return id.info().Yards
+}
+
+func (id StatID) IsTouchdown() bool {
+	return id.info().Touchdown
+}
+
+func (id StatID) IsTurnover() bool {
+	return id.info().Turnover
+}
+
+func (id StatID) IsFirstDown() bool {
+	return id.info().FirstDown
+}
+
+// The stat's name, or an empty string if the stat ID is unknown.
+func (s StatFilePlayStat) statName() string {
+	return s.StatID.info().Name
+}
+
+// MarshalJSON adds the stat's name to make the output self-describing. The name is ignored when
+// unmarshaling.
+func (s StatFilePlayStat) MarshalJSON() ([]byte, error) {
+	type plain StatFilePlayStat
+	return json.Marshal(struct {
+		plain
+		StatName string `json:",omitempty"`
+	}{
+		plain:    plain(s),
+		StatName: s.statName(),
+	})
+}
+
+// MarshalXML adds the stat's name as an attribute to make the output self-describing. The name is
+// ignored when unmarshaling.
+func (s StatFilePlayStat) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type plain StatFilePlayStat
+	if name := s.statName(); name != "" {
+		start.Attr = append(start.Attr, xml.Attr{
+			Name:  xml.Name{Local: "StatName"},
+			Value: name,
+		})
+	}
+	return e.EncodeElement(plain(s), start)
+}
@@ -0,0 +1,97 @@
+package gsis
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatID(t *testing.T) {
+	assert.Equal(t, "Rushing Yards, TD", StatIDRushingYardsTD.String())
+	assert.Equal(t, "StatID(9999)", StatID(9999).String())
+	_, ok := StatID(9999).Info()
+	assert.False(t, ok)
+
+	// Info returns a copy, so changing it doesn't change the stat.
+	info, ok := StatIDRushingYardsTD.Info()
+	require.True(t, ok)
+	info.Name = "changed"
+	assert.Equal(t, "Rushing Yards, TD", StatIDRushingYardsTD.String())
+	assert.Equal(t, StatCategory(""), StatID(9999).Category())
+
+	assert.Equal(t, StatCategoryRushing, StatIDRushingYardsTD.Category())
+	assert.Equal(t, StatSideOffense, StatIDRushingYardsTD.Side())
+	assert.Equal(t, StatYardsMeaningGain, StatIDRushingYardsTD.YardsMeaning())
+	assert.True(t, StatIDRushingYardsTD.IsTouchdown())
+	assert.False(t, StatIDRushingYardsTD.IsTurnover())
+
+	assert.True(t, StatIDInterceptionPasser.IsTurnover())
+	assert.True(t, StatIDFumbleLost.IsTurnover())
+	assert.True(t, StatIDFirstDownPenalty.IsFirstDown())
+	assert.Equal(t, StatSideSpecialTeams, StatIDPuntReturnYards.Side())
+	assert.Equal(t, StatYardsMeaningKickDistance, StatIDFieldGoalYards.YardsMeaning())
+	assert.Equal(t, StatYardsMeaningPenalty, StatIDPenalty.YardsMeaning())
+	assert.Equal(t, StatSideNone, StatIDPenalty.Side())
+
+	ids := StatIDs()
+	require.NotEmpty(t, ids)
+	for i, id := range ids {
+		if i > 0 {
+			assert.Greater(t, int(id), int(ids[i-1]))
+		}
+		info, ok := id.Info()
+		require.True(t, ok)
+		assert.Equal(t, id, info.ID)
+		assert.NotEmpty(t, info.Name)
+		assert.NotEmpty(t, info.Category)
+	}
+}
+
+func TestStatID_FeedNames(t *testing.T) {
+	buf, err := ioutil.ReadFile("testdata/signalr-stats.json")
+	require.NoError(t, err)
+
+	// The SignalR feed includes the name of each stat as the "PlayDescription".
+	var feed struct {
+		PlayStat []struct {
+			StatID          StatID
+			PlayDescription string
+		}
+	}
+	require.NoError(t, json.Unmarshal(buf, &feed))
+	require.NotEmpty(t, feed.PlayStat)
+	for _, stat := range feed.PlayStat {
+		assert.Equal(t, stat.PlayDescription, stat.StatID.String())
+	}
+}
+
+func TestStatFilePlayStat_Marshal(t *testing.T) {
+	yards := 7
+	stat := StatFilePlayStat{
+		PlayID:        42,
+		StatID:        StatIDRushingYardsTD,
+		ClubCode:      "SEA",
+		PlayerID:      "00-0032950",
+		PlayerName:    "C.Carson",
+		Yards:         StatYards{Value: &yards},
+		UniformNumber: "32",
+	}
+
+	buf, err := json.Marshal(stat)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"PlayID":42,"StatID":11,"StatName":"Rushing Yards, TD","ClubCode":"SEA","PlayerID":"00-0032950","PlayerName":"C.Carson","Yards":"7","UniformNumber":"32"}`, string(buf))
+	var fromJSON StatFilePlayStat
+	require.NoError(t, json.Unmarshal(buf, &fromJSON))
+	assert.Equal(t, stat, fromJSON)
+
+	buf, err = xml.Marshal(stat)
+	require.NoError(t, err)
+	assert.Contains(t, string(buf), `StatName="Rushing Yards, TD"`)
+	var fromXML StatFilePlayStat
+	require.NoError(t, xml.Unmarshal(buf, &fromXML))
+	assert.Equal(t, stat, fromXML)
+}
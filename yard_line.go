@@ -0,0 +1,179 @@
+package gsis
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A spot on the field, e.g. "SEA 35". Midfield is "50" and has no team.
+type YardLine struct {
+	team   *string
+	number *int
+	raw    string
+}
+
+// NewYardLine returns the yard line in the given team's half of the field. The team may be empty
+// for midfield.
+func NewYardLine(team string, number int) *YardLine {
+	l := &YardLine{
+		number: &number,
+	}
+	if team != "" {
+		l.team = &team
+	}
+	return l
+}
+
+// NewYardLineFromDistance returns the yard line the given distance from the team's own goal line.
+// Distances are clamped to the goal lines, so 100 or more is the opponent's goal line.
+func NewYardLineFromDistance(distance int, team, opponent string) *YardLine {
+	switch {
+	case distance < 0:
+		distance = 0
+	case distance > 100:
+		distance = 100
+	}
+	switch {
+	case distance < 50:
+		return NewYardLine(team, distance)
+	case distance > 50:
+		return NewYardLine(opponent, 100-distance)
+	}
+	return NewYardLine("", 50)
+}
+
+// IsNil returns true if the yard line is empty, which is the case for plays such as timeouts.
+func (l *YardLine) IsNil() bool {
+	return l.number == nil
+}
+
+func (l *YardLine) Team() *string {
+	return l.team
+}
+
+// Number returns the yard line's number, or zero if the yard line is empty.
+func (l *YardLine) Number() int {
+	if l.number == nil {
+		return 0
+	}
+	return *l.number
+}
+
+func (l *YardLine) String() string {
+	if l.number != nil {
+		if l.team != nil {
+			return fmt.Sprintf("%v %v", *(l.team), *(l.number))
+		}
+		return fmt.Sprintf("%v", *(l.number))
+	}
+	return ""
+}
+
+func normalizeYardLineTeam(team string) string {
+	return CommonTeamAbbreviation(strings.ToUpper(team))
+}
+
+// Normalized returns the yard line with its team converted to the common abbreviation, e.g.
+// "blt 20" becomes "BAL 20". Midfield never has a team.
+func (l *YardLine) Normalized() *YardLine {
+	if l.number == nil {
+		return &YardLine{}
+	}
+	if l.team == nil || *l.number == 50 {
+		return NewYardLine("", *l.number)
+	}
+	return NewYardLine(normalizeYardLineTeam(*l.team), *l.number)
+}
+
+// Distance returns the distance from the team's own goal line to the yard line, where the team's
+// own 20 is 20 and the opponent's 20 is 80. Team codes are compared using CommonTeamAbbreviation.
+// It returns false if the yard line is empty, or if it has no team and isn't midfield.
+func (l *YardLine) Distance(team string) (int, bool) {
+	if l.number == nil {
+		return 0, false
+	}
+	number := *l.number
+	switch {
+	case number == 50:
+		return 50, true
+	case l.team == nil:
+		return 0, false
+	case normalizeYardLineTeam(*l.team) == normalizeYardLineTeam(team):
+		return number, true
+	}
+	return 100 - number, true
+}
+
+// Add moves the yard line the given number of yards towards the opponent's goal line. The result
+// stops at the goal lines. It returns false if the yard line's distance is unknown.
+func (l *YardLine) Add(yards int, team, opponent string) (*YardLine, bool) {
+	distance, ok := l.Distance(team)
+	if !ok {
+		return nil, false
+	}
+	return NewYardLineFromDistance(distance+yards, team, opponent), true
+}
+
+// Sub moves the yard line the given number of yards towards the team's own goal line. The result
+// stops at the goal lines. It returns false if the yard line's distance is unknown.
+func (l *YardLine) Sub(yards int, team, opponent string) (*YardLine, bool) {
+	return l.Add(-yards, team, opponent)
+}
+
+// Returns the text the yard line was parsed from, or its string if it was constructed.
+func (l YardLine) text() string {
+	if l.raw != "" {
+		return l.raw
+	}
+	return l.String()
+}
+
+func (l YardLine) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{
+		Name:  name,
+		Value: l.text(),
+	}, nil
+}
+
+func (l YardLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.text())
+}
+
+func (l *YardLine) unmarshal(s string) error {
+	if s == "" {
+		*l = YardLine{}
+		return nil
+	}
+	values := strings.Split(s, " ")
+	if len(values) > 2 {
+		return fmt.Errorf("expected <= 2 values, found %d", len(values))
+	}
+	newYardLine := YardLine{
+		raw: s,
+	}
+	if len(values) > 1 {
+		newYardLine.team = &values[0]
+	}
+	if v, err := strconv.Atoi(values[len(values)-1]); err != nil {
+		return err
+	} else {
+		newYardLine.number = &v
+	}
+	*l = newYardLine
+	return nil
+}
+
+func (l *YardLine) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return l.unmarshal(s)
+}
+
+func (l *YardLine) UnmarshalXMLAttr(attr xml.Attr) error {
+	return l.unmarshal(attr.Value)
+}
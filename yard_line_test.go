@@ -0,0 +1,127 @@
+package gsis
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYardLine(t *testing.T) {
+	var empty YardLine
+	assert.True(t, empty.IsNil())
+	assert.Equal(t, 0, empty.Number())
+	assert.Nil(t, empty.Team())
+	assert.Equal(t, "", empty.String())
+	_, ok := empty.Distance("SEA")
+	assert.False(t, ok)
+	_, ok = empty.Add(10, "SEA", "SF")
+	assert.False(t, ok)
+
+	l := NewYardLine("SEA", 20)
+	assert.False(t, l.IsNil())
+	assert.Equal(t, "SEA 20", l.String())
+
+	distance, ok := l.Distance("SEA")
+	assert.True(t, ok)
+	assert.Equal(t, 20, distance)
+	distance, ok = l.Distance("SF")
+	assert.True(t, ok)
+	assert.Equal(t, 80, distance)
+
+	midfield := NewYardLine("", 50)
+	assert.Nil(t, midfield.Team())
+	assert.Equal(t, "50", midfield.String())
+	distance, ok = midfield.Distance("SF")
+	assert.True(t, ok)
+	assert.Equal(t, 50, distance)
+
+	// Without a team, only midfield is meaningful.
+	_, ok = NewYardLine("", 35).Distance("SEA")
+	assert.False(t, ok)
+
+	for distance, expected := range map[int]string{
+		-5:  "SEA 0",
+		0:   "SEA 0",
+		20:  "SEA 20",
+		50:  "50",
+		65:  "SF 35",
+		100: "SF 0",
+		110: "SF 0",
+	} {
+		assert.Equal(t, expected, NewYardLineFromDistance(distance, "SEA", "SF").String())
+	}
+}
+
+func TestYardLine_Add(t *testing.T) {
+	for _, tc := range []struct {
+		Start    *YardLine
+		Yards    int
+		Expected string
+	}{
+		{NewYardLine("SEA", 20), 15, "SEA 35"},
+		{NewYardLine("SEA", 45), 5, "50"},
+		{NewYardLine("SEA", 45), 10, "SF 45"},
+		{NewYardLine("SF", 5), 10, "SF 0"},
+		{NewYardLine("SF", 45), -10, "SEA 45"},
+		{NewYardLine("SEA", 3), -10, "SEA 0"},
+		{NewYardLine("", 50), 7, "SF 43"},
+	} {
+		l, ok := tc.Start.Add(tc.Yards, "SEA", "SF")
+		require.True(t, ok)
+		assert.Equal(t, tc.Expected, l.String(), "%v + %v", tc.Start, tc.Yards)
+
+		l, ok = tc.Start.Sub(-tc.Yards, "SEA", "SF")
+		require.True(t, ok)
+		assert.Equal(t, tc.Expected, l.String(), "%v - %v", tc.Start, -tc.Yards)
+	}
+}
+
+func TestYardLine_Normalized(t *testing.T) {
+	assert.Equal(t, NewYardLine("BAL", 20), NewYardLine("blt", 20).Normalized())
+	assert.Equal(t, NewYardLine("LAR", 7), NewYardLine("LA", 7).Normalized())
+	assert.Equal(t, NewYardLine("SEA", 7), NewYardLine("SEA", 7).Normalized())
+	assert.Equal(t, NewYardLine("", 50), NewYardLine("SEA", 50).Normalized())
+	assert.True(t, (&YardLine{}).Normalized().IsNil())
+
+	// Team codes are normalized for comparison too.
+	distance, ok := NewYardLine("BLT", 30).Distance("BAL")
+	assert.True(t, ok)
+	assert.Equal(t, 30, distance)
+}
+
+func TestYardLine_Marshal(t *testing.T) {
+	var v struct {
+		XMLName  xml.Name `xml:"Play"`
+		YardLine YardLine `xml:",attr"`
+	}
+
+	require.NoError(t, xml.Unmarshal([]byte(`<Play YardLine="SEA 35"></Play>`), &v))
+	assert.Equal(t, 35, v.YardLine.Number())
+	buf, err := xml.Marshal(v)
+	require.NoError(t, err)
+	assert.Equal(t, `<Play YardLine="SEA 35"></Play>`, string(buf))
+
+	v.YardLine = *NewYardLine("", 50)
+	buf, err = xml.Marshal(v)
+	require.NoError(t, err)
+	assert.Equal(t, `<Play YardLine="50"></Play>`, string(buf))
+
+	buf, err = json.Marshal(NewYardLine("SF", 12))
+	require.NoError(t, err)
+	assert.Equal(t, `"SF 12"`, string(buf))
+	var l YardLine
+	require.NoError(t, json.Unmarshal(buf, &l))
+	assert.Equal(t, "SF 12", l.String())
+
+	buf, err = json.Marshal(YardLine{})
+	require.NoError(t, err)
+	assert.Equal(t, `""`, string(buf))
+	require.NoError(t, json.Unmarshal(buf, &l))
+	assert.True(t, l.IsNil())
+
+	assert.Error(t, json.Unmarshal([]byte(`"SEA 35 40"`), &l))
+	assert.Error(t, json.Unmarshal([]byte(`"SEA"`), &l))
+}